@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+// controller scrapes a single Unleashed network, caching the result for ttl.
+type controller struct {
+	name   string
+	client *ruckusweb.Client
+	ttl    time.Duration
+
+	m       sync.Mutex
+	expires time.Time
+	cached  *families
+	err     error
+}
+
+// failedScrapeTTL caps how long a failed scrape is cached, so that the next scrape after a transient failure retries
+// the controller instead of reporting it down for the whole ttl.
+const failedScrapeTTL = 5 * time.Second
+
+// metrics returns the controller's metrics, scraping it if the cached copy has expired. A successful scrape is cached
+// for ttl and a failed one for at most failedScrapeTTL. Concurrent callers share a single scrape.
+func (c *controller) metrics(ctx context.Context) (*families, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if !time.Now().Before(c.expires) {
+		start := time.Now()
+		fs, err := collect(ctx, c.client)
+		scrapedAt := time.Now()
+		ttl := c.ttl
+		if err != nil && ttl > failedScrapeTTL {
+			ttl = failedScrapeTTL
+		}
+		c.expires = scrapedAt.Add(ttl)
+		if fs == nil {
+			fs = &families{}
+		}
+		fs.gauge("ruckus_scrape_duration_seconds", "Time taken to scrape the controller.", scrapedAt.Sub(start).Seconds())
+		c.cached, c.err = fs, err
+	}
+
+	up := 0.0
+	if c.err == nil {
+		up = 1
+	}
+	fs := &families{}
+	fs.gauge("ruckus_up", "Whether the last scrape of the controller succeeded.", up, label{"controller", c.name})
+	for _, name := range c.cached.order {
+		f := c.cached.byName[name]
+		for _, s := range f.Samples {
+			fs.add(f.Name, f.Help, f.Type, s.Value, append([]label{{"controller", c.name}}, s.Labels...)...)
+		}
+	}
+	return fs, c.err
+}
+
+func collect(ctx context.Context, client *ruckusweb.Client) (*families, error) {
+	aps, err := client.APs().ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wlans, err := client.ListStatuses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wlanConfigs, err := client.Wlans().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stations, err := client.Stations().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := &families{}
+	collectAPs(fs, aps)
+	collectWlans(fs, wlans, wlanConfigs)
+	collectStations(fs, stations)
+	return fs, nil
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// lastBytes returns the most recent value in a time series.
+func lastBytes(ts ruckusweb.ByteTimeSeries) (float64, bool) {
+	if len(ts) == 0 {
+		return 0, false
+	}
+	return float64(ts[len(ts)-1].Bytes), true
+}
+
+func collectAPs(fs *families, aps []ruckusweb.APStatus) {
+	for _, ap := range aps {
+		mac := net.HardwareAddr(ap.Mac).String()
+		apLabels := []label{{"ap", ap.Devname}, {"mac", mac}}
+
+		fs.gauge("ruckus_ap_info", "Static information about an AP.", 1,
+			append(apLabels, label{"model", ap.Model}, label{"firmware", ap.FirmwareVersion}, label{"role", ap.Role})...)
+		fs.gauge("ruckus_ap_connected", "Whether the AP is connected to the master.", boolValue(ap.State == "1"), apLabels...)
+		fs.gauge("ruckus_ap_state", "The AP's raw state code.", parseFloat(ap.State), apLabels...)
+		fs.gauge("ruckus_ap_mesh_depth", "The number of mesh hops between the AP and a wired uplink.", float64(ap.MeshDepth), apLabels...)
+
+		for _, radio := range ap.Radio {
+			radioLabels := append(apLabels[:len(apLabels):len(apLabels)], label{"band", radio.RadioBand})
+			fs.gauge("ruckus_ap_radio_channel", "The radio's current channel.", float64(radio.Channel), radioLabels...)
+			fs.gauge("ruckus_ap_radio_channelization", "The radio's channel width in MHz.", float64(radio.Channelization), radioLabels...)
+		}
+
+		for _, series := range []struct {
+			name string
+			help string
+			band string
+			ts   ruckusweb.ByteTimeSeries
+		}{
+			{"ruckus_ap_rx_bytes", "Bytes received by the AP in the most recent history interval.", "2.4g", ap.History.RxBytes24g},
+			{"ruckus_ap_tx_bytes", "Bytes transmitted by the AP in the most recent history interval.", "2.4g", ap.History.TxBytes24g},
+			{"ruckus_ap_rx_bytes", "Bytes received by the AP in the most recent history interval.", "5g", ap.History.RxBytes5g},
+			{"ruckus_ap_tx_bytes", "Bytes transmitted by the AP in the most recent history interval.", "5g", ap.History.TxBytes5g},
+		} {
+			if value, ok := lastBytes(series.ts); ok {
+				fs.gauge(series.name, series.help, value, append(apLabels[:len(apLabels):len(apLabels)], label{"band", series.band})...)
+			}
+		}
+	}
+}
+
+// collectWlans labels each WLAN by name, since several WLANs can share an SSID, e.g. on different VLANs.
+func collectWlans(fs *families, wlans []ruckusweb.WlanStatus, configs []ruckusweb.Wlan) {
+	names := make(map[int]string, len(configs))
+	for _, config := range configs {
+		names[config.ID] = config.Name
+	}
+
+	for _, wlan := range wlans {
+		name, ok := names[wlan.ID]
+		if !ok {
+			name = strconv.Itoa(wlan.ID)
+		}
+		wlanLabels := []label{{"wlan", name}, {"ssid", wlan.Ssid}}
+		fs.gauge("ruckus_wlan_associated_stations", "The number of stations associated with the WLAN.", float64(wlan.AssocStas), wlanLabels...)
+	}
+}
+
+func collectStations(fs *families, stations []ruckusweb.Station) {
+	for _, station := range stations {
+		name := station.Hostname
+		if name == "" {
+			name = station.OriginalName
+		}
+		labels := []label{
+			{"mac", net.HardwareAddr(station.Mac).String()},
+			{"client", name},
+			{"ap", station.ApName},
+			{"wlan", station.Wlan},
+			{"band", station.RadioBand},
+		}
+
+		fs.gauge("ruckus_station_rssi", "The station's received signal strength.", float64(station.Rssi), labels...)
+		fs.gauge("ruckus_station_noise_floor_dbm", "The noise floor on the station's channel.", float64(station.NoiseFloor), labels...)
+		fs.gauge("ruckus_station_channel", "The channel the station is using.", float64(station.Channel), labels...)
+
+		for _, counter := range []struct {
+			name  string
+			help  string
+			value uint64
+		}{
+			{"ruckus_station_rx_bytes_total", "Bytes received from the station.", station.TotalRxBytes},
+			{"ruckus_station_tx_bytes_total", "Bytes transmitted to the station.", station.TotalTxBytes},
+			{"ruckus_station_rx_packets_total", "Packets received from the station.", station.TotalRxPkts},
+			{"ruckus_station_tx_packets_total", "Packets transmitted to the station.", station.TotalTxPkts},
+			{"ruckus_station_retries_total", "Transmission retries to the station.", station.TotalRetries},
+			{"ruckus_station_retry_bytes_total", "Bytes retransmitted to the station.", station.TotalRetryBytes},
+			{"ruckus_station_rx_crc_errors_total", "Frames from the station with CRC errors.", station.TotalRxCrcErrs},
+			{"ruckus_station_rx_duplicates_total", "Duplicate frames received from the station.", station.TotalRxDup},
+			{"ruckus_station_tx_drop_data_total", "Data frames to the station which were dropped.", station.TxDropData},
+			{"ruckus_station_tx_drop_mgmt_total", "Management frames to the station which were dropped.", station.TxDropMgmt},
+		} {
+			fs.counter(counter.name, counter.help, float64(counter.value), labels...)
+		}
+	}
+}
+
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return -1
+	}
+	return f
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the exporter's configuration file. JSON is valid YAML, so either format may be used.
+type Config struct {
+	// Listen is the address to serve /metrics on.
+	Listen string `yaml:"listen"`
+	// CacheTTL is how long a controller's metrics are reused before it is scraped again.
+	CacheTTL time.Duration `yaml:"cache_ttl"`
+
+	Controllers []ControllerConfig `yaml:"controllers"`
+}
+
+type ControllerConfig struct {
+	// Name labels every metric from this controller.
+	Name     string `yaml:"name"`
+	Host     string `yaml:"host"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	// InsecureSkipVerify disables TLS certificate verification, which is needed for the device's self-signed
+	// certificate.
+	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+}
+
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := Config{
+		Listen:   ":9345",
+		CacheTTL: 30 * time.Second,
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %v", path, err)
+	}
+
+	if len(config.Controllers) == 0 {
+		return nil, errors.New("no controllers configured")
+	}
+	names := make(map[string]bool)
+	for i, controller := range config.Controllers {
+		if controller.Name == "" {
+			return nil, fmt.Errorf("controller %d: name must be set", i)
+		}
+		if controller.Host == "" {
+			return nil, fmt.Errorf("controller %q: host must be set", controller.Name)
+		}
+		if names[controller.Name] {
+			return nil, fmt.Errorf("controller %q: duplicate name", controller.Name)
+		}
+		names[controller.Name] = true
+	}
+
+	return &config, nil
+}
+
+func (c ControllerConfig) newClient() *ruckusweb.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
+	return ruckusweb.NewClient(transport, c.Host, ruckusweb.Credentials{
+		Username: c.Username,
+		Password: c.Password,
+	})
+}
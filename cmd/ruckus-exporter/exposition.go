@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// family is a set of samples sharing a metric name, written in the Prometheus text exposition format.
+type family struct {
+	Name    string
+	Help    string
+	Type    string // "gauge" or "counter"
+	Samples []sample
+}
+
+type sample struct {
+	Labels []label
+	Value  float64
+}
+
+type label struct {
+	Name  string
+	Value string
+}
+
+// families collects metric families by name, preserving the order in which they were first added.
+type families struct {
+	order  []string
+	byName map[string]*family
+}
+
+func (fs *families) add(name, help, typ string, value float64, labels ...label) {
+	if fs.byName == nil {
+		fs.byName = make(map[string]*family)
+	}
+	f, ok := fs.byName[name]
+	if !ok {
+		f = &family{Name: name, Help: help, Type: typ}
+		fs.byName[name] = f
+		fs.order = append(fs.order, name)
+	}
+	f.Samples = append(f.Samples, sample{Labels: labels, Value: value})
+}
+
+func (fs *families) gauge(name, help string, value float64, labels ...label) {
+	fs.add(name, help, "gauge", value, labels...)
+}
+
+func (fs *families) counter(name, help string, value float64, labels ...label) {
+	fs.add(name, help, "counter", value, labels...)
+}
+
+// merge appends every sample in other to fs.
+func (fs *families) merge(other *families) {
+	for _, name := range other.order {
+		f := other.byName[name]
+		for _, s := range f.Samples {
+			fs.add(f.Name, f.Help, f.Type, s.Value, s.Labels...)
+		}
+	}
+}
+
+func (fs *families) writeTo(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	names := append([]string(nil), fs.order...)
+	sort.Strings(names)
+	for _, name := range names {
+		f := fs.byName[name]
+		bw.WriteString("# HELP " + f.Name + " " + escapeHelp(f.Help) + "\n")
+		bw.WriteString("# TYPE " + f.Name + " " + f.Type + "\n")
+		for _, s := range f.Samples {
+			bw.WriteString(f.Name)
+			if len(s.Labels) > 0 {
+				bw.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					bw.WriteString(l.Name + `="` + escapeLabelValue(l.Value) + `"`)
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(strconv.FormatFloat(s.Value, 'g', -1, 64))
+			bw.WriteByte('\n')
+		}
+	}
+	return bw.Flush()
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTo(t *testing.T) {
+	a := &families{}
+	a.gauge("ruckus_up", "Whether the scrape succeeded.", 1, label{"controller", "home"})
+	a.counter("ruckus_station_rx_bytes_total", "Bytes received.", 1.5e9, label{"client", `my "laptop"`}, label{"ap", `back\slash`})
+
+	b := &families{}
+	b.gauge("ruckus_up", "Whether the scrape succeeded.", 0, label{"controller", "office"})
+	b.gauge("ruckus_scrape_duration_seconds", "Time taken\nto scrape.", 0.25)
+
+	all := &families{}
+	all.merge(a)
+	all.merge(b)
+
+	var buf bytes.Buffer
+	require.NoError(t, all.writeTo(&buf))
+	assert.Equal(t, `# HELP ruckus_scrape_duration_seconds Time taken\nto scrape.
+# TYPE ruckus_scrape_duration_seconds gauge
+ruckus_scrape_duration_seconds 0.25
+# HELP ruckus_station_rx_bytes_total Bytes received.
+# TYPE ruckus_station_rx_bytes_total counter
+ruckus_station_rx_bytes_total{client="my \"laptop\"",ap="back\\slash"} 1.5e+09
+# HELP ruckus_up Whether the scrape succeeded.
+# TYPE ruckus_up gauge
+ruckus_up{controller="home"} 1
+ruckus_up{controller="office"} 0
+`, buf.String())
+}
@@ -0,0 +1,100 @@
+// Command ruckus-exporter serves Prometheus metrics for one or more Ruckus Unleashed networks.
+//
+// Usage:
+//
+//	ruckus-exporter -config ruckus-exporter.yaml
+//
+// The configuration file lists the controllers to scrape:
+//
+//	listen: ":9345"
+//	cache_ttl: 30s
+//	controllers:
+//	  - name: office
+//	    host: unleashed.example.com
+//	    username: admin
+//	    password: secret
+//	    insecure_skip_verify: true
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+func main() {
+	configPath := flag.String("config", "ruckus-exporter.yaml", "path to the configuration file")
+	verbose := flag.Bool("verbose", false, "log every request sent to the controllers")
+	flag.Parse()
+
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	var controllers []*controller
+	for _, cc := range config.Controllers {
+		client := cc.newClient()
+		if *verbose {
+			client.SetTraceLog(logger)
+		} else {
+			client.SetTraceLog(nil)
+		}
+		controllers = append(controllers, &controller{
+			name:   cc.Name,
+			client: client,
+			ttl:    config.CacheTTL,
+		})
+	}
+
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+		defer cancel()
+
+		results := make([]*families, len(controllers))
+		var wg sync.WaitGroup
+		for i, c := range controllers {
+			wg.Add(1)
+			go func(i int, c *controller) {
+				defer wg.Done()
+				fs, err := c.metrics(ctx)
+				if err != nil {
+					logger.Printf("scraping %s: %v", c.name, err)
+				}
+				results[i] = fs
+			}(i, c)
+		}
+		wg.Wait()
+
+		all := &families{}
+		for _, fs := range results {
+			all.merge(fs)
+		}
+
+		var buf bytes.Buffer
+		if err := all.writeTo(&buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	})
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "<html><body><a href=\"/metrics\">Metrics</a></body></html>\n")
+	})
+
+	logger.Printf("listening on %s", config.Listen)
+	logger.Fatal(http.ListenAndServe(config.Listen, nil))
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"strconv"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+func cmdAPs(e *env, args []string) error {
+	return subcommand(e, "aps", args, map[string]command{
+		"list":   apsList,
+		"status": apsStatus,
+	})
+}
+
+func apsList(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl aps list")
+	}
+	aps, err := e.client.APs().List(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"ID", "MAC", "NAME", "MODEL", "IP", "GROUP", "VERSION", "LOCATION"}}
+	for _, ap := range aps {
+		t.add(ap.ID, net.HardwareAddr(ap.Mac), ap.Devname, ap.Model, ap.Ip, ap.GroupID, ap.Version, ap.Location)
+	}
+	return e.format.print(e.stdout, aps, t)
+}
+
+func apsStatus(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl aps status")
+	}
+	statuses, err := e.client.APs().ListStatuses(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"MAC", "NAME", "STATE", "IP", "FIRMWARE", "MESH", "CHANNELS"}}
+	for _, status := range statuses {
+		t.add(net.HardwareAddr(status.Mac), status.Devname, status.State, status.Ip, status.FirmwareVersion, status.MeshState, radioChannels(status.Radio))
+	}
+	return e.format.print(e.stdout, statuses, t)
+}
+
+func radioChannels(radios []ruckusweb.APStatusRadio) string {
+	var s string
+	for i, radio := range radios {
+		if i > 0 {
+			s += " "
+		}
+		s += radio.RadioBand + ":" + strconv.Itoa(radio.Channel)
+	}
+	return s
+}
+
+func cmdAPGroups(e *env, args []string) error {
+	return subcommand(e, "apgroups", args, map[string]command{
+		"list": apGroupsList,
+	})
+}
+
+func apGroupsList(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl apgroups list")
+	}
+	groups, err := e.client.APGroups().List(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"ID", "NAME", "WLANS", "DESCRIPTION"}}
+	for _, group := range groups {
+		t.add(group.ID, group.Name, len(group.Wlangroup.Wlansvc), group.Description)
+	}
+	return e.format.print(e.stdout, groups, t)
+}
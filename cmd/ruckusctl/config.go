@@ -0,0 +1,72 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the controller address and credentials. It is read from a YAML or JSON file, and each field can be
+// overridden by an environment variable.
+type Config struct {
+	Host               string `yaml:"host"`                 // RUCKUS_HOST
+	Username           string `yaml:"username"`             // RUCKUS_USERNAME
+	Password           string `yaml:"password"`             // RUCKUS_PASSWORD
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // RUCKUS_INSECURE_SKIP_VERIFY=1
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "ruckusctl", "config.yaml")
+}
+
+// loadConfig reads path, if it exists, and applies environment overrides. An explicitly requested path must exist.
+func loadConfig(path string, explicit bool) (*Config, error) {
+	var config Config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && (explicit || !errors.Is(err, os.ErrNotExist)) {
+			return nil, err
+		} else if err == nil {
+			if err := yaml.Unmarshal(data, &config); err != nil {
+				return nil, fmt.Errorf("parsing %s: %v", path, err)
+			}
+		}
+	}
+
+	if v := os.Getenv("RUCKUS_HOST"); v != "" {
+		config.Host = v
+	}
+	if v := os.Getenv("RUCKUS_USERNAME"); v != "" {
+		config.Username = v
+	}
+	if v := os.Getenv("RUCKUS_PASSWORD"); v != "" {
+		config.Password = v
+	}
+	if v := os.Getenv("RUCKUS_INSECURE_SKIP_VERIFY"); v != "" {
+		config.InsecureSkipVerify = v == "1" || v == "true"
+	}
+
+	if config.Host == "" {
+		return nil, errors.New("no host configured: set RUCKUS_HOST or use a config file")
+	}
+	return &config, nil
+}
+
+func (c Config) newClient() *ruckusweb.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify}
+	return ruckusweb.NewClient(transport, c.Host, ruckusweb.Credentials{
+		Username: c.Username,
+		Password: c.Password,
+	})
+}
@@ -0,0 +1,131 @@
+// Command ruckusctl manages a Ruckus Unleashed network from the command line.
+//
+// Usage:
+//
+//	ruckusctl [flags] <command> [<subcommand>] [args]
+//
+// The controller is configured by a YAML or JSON file (by default ruckusctl/config.yaml in the user's configuration
+// directory) containing host, username, password and insecure_skip_verify, or by the RUCKUS_HOST, RUCKUS_USERNAME,
+// RUCKUS_PASSWORD and RUCKUS_INSECURE_SKIP_VERIFY environment variables.
+//
+// With -dry-run, changes are printed to stderr as the XML that would be sent instead of being applied, leaving stdout
+// for the command's usual output.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+type env struct {
+	ctx    context.Context
+	client *ruckusweb.Client
+	format outputFormat
+	dryRun bool
+	stdout io.Writer
+	// stderr receives dry-run output, keeping it apart from the command's results
+	stderr io.Writer
+}
+
+type command func(e *env, args []string) error
+
+var commands = map[string]command{
+	"wlans":    cmdWlans,
+	"aps":      cmdAPs,
+	"apgroups": cmdAPGroups,
+	"stations": cmdStations,
+	"snmp":     cmdSNMP,
+	"tls":      cmdTLS,
+	"sysinfo":  cmdSysinfo,
+	"snapshot": cmdSnapshot,
+	"diff":     cmdDiff,
+	"users":    cmdUsers,
+}
+
+// subcommand dispatches args[0] to one of subcommands.
+func subcommand(e *env, name string, args []string, subcommands map[string]command) error {
+	var names []string
+	for n := range subcommands {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	if len(args) == 0 {
+		return fmt.Errorf("usage: ruckusctl %s <%s>", name, strings.Join(names, "|"))
+	}
+	cmd, ok := subcommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q: expected one of %s", name+" "+args[0], strings.Join(names, ", "))
+	}
+	return cmd(e, args[1:])
+}
+
+func usage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: ruckusctl [flags] <%s> ...\n\nflags:\n", strings.Join(names, "|"))
+	flag.PrintDefaults()
+}
+
+func main() {
+	defaultConfig := defaultConfigPath()
+	configPath := flag.String("config", defaultConfig, "path to the configuration file")
+	format := formatTable
+	flag.Var(&format, "o", "output format: table, json or yaml")
+	dryRun := flag.Bool("dry-run", false, "print changes as XML instead of applying them")
+	verbose := flag.Bool("verbose", false, "log every request sent to the controller")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "ruckusctl: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	config, err := loadConfig(*configPath, *configPath != defaultConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ruckusctl: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	e := &env{
+		ctx:    ctx,
+		client: config.newClient(),
+		format: format,
+		dryRun: *dryRun,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	if !*verbose {
+		e.client.SetTraceLog(nil)
+	}
+	if e.dryRun {
+		e.client.SetDryRun(e.stderr)
+	}
+
+	if err := cmd(e, flag.Args()[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "ruckusctl: %v\n", err)
+		os.Exit(1)
+	}
+}
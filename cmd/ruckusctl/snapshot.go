@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+func cmdSnapshot(e *env, args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	secrets := fs.Bool("secrets", false, "include passphrases, shared secrets and SNMP communities")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: ruckusctl snapshot [-secrets]")
+	}
+
+	snapshot, err := e.client.Snapshot(e.ctx, ruckusweb.SnapshotOptions{IncludeSecrets: *secrets})
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(e.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snapshot)
+}
+
+// cmdDiff compares a saved snapshot with another saved snapshot or with the live network, failing if they differ.
+func cmdDiff(e *env, args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("usage: ruckusctl diff <old.json> [<new.json>]")
+	}
+
+	old, err := readSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	var current *ruckusweb.Snapshot
+	if len(args) == 2 {
+		current, err = readSnapshot(args[1])
+	} else {
+		current, err = e.client.Snapshot(e.ctx, ruckusweb.SnapshotOptions{IncludeSecrets: old.IncludesSecrets})
+	}
+	if err != nil {
+		return err
+	}
+
+	diffs, err := ruckusweb.Diff(old, current)
+	if err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		fmt.Fprintln(e.stdout, d)
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%d differences", len(diffs))
+	}
+	return nil
+}
+
+func readSnapshot(path string) (*ruckusweb.Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot ruckusweb.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if snapshot.Version != ruckusweb.SnapshotVersion {
+		return nil, fmt.Errorf("%s: unsupported snapshot version %d", path, snapshot.Version)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+func cmdSNMP(e *env, args []string) error {
+	return subcommand(e, "snmp", args, map[string]command{
+		"get": snmpGet,
+		"set": snmpSet,
+	})
+}
+
+func snmpGet(e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: ruckusctl snmp get <v2|v3|trap>")
+	}
+
+	switch args[0] {
+	case "v2":
+		settings, err := e.client.SNMP().GetV2(e.ctx)
+		if err != nil {
+			return err
+		}
+		t := &table{headers: []string{"ENABLED", "CONTACT", "LOCATION", "RO COMMUNITY", "RW COMMUNITY"}}
+		t.add(settings.Enabled, settings.SysContact, settings.SysLocation, settings.RoCommunity, settings.RwCommunity)
+		return e.format.print(e.stdout, settings, t)
+
+	case "v3":
+		settings, err := e.client.SNMP().GetV3(e.ctx)
+		if err != nil {
+			return err
+		}
+		t := &table{headers: []string{"ROLE", "NAME", "AUTH", "PRIV"}}
+		for _, user := range settings.Snmpusr {
+			t.add(user.Role, user.Name, user.Auth, user.Priv)
+		}
+		return e.format.print(e.stdout, settings, t)
+
+	case "trap":
+		settings, err := e.client.SNMP().GetTrap(e.ctx)
+		if err != nil {
+			return err
+		}
+		t := &table{headers: []string{"ENABLED", "VERSION", "COMMUNITY", "TARGETS"}}
+		t.add(settings.Enabled, settings.Ver, settings.Community, fmt.Sprint(settings.Ip1, " ", settings.Ip2, " ", settings.Ip3, " ", settings.Ip4))
+		return e.format.print(e.stdout, settings, t)
+
+	default:
+		return fmt.Errorf("unknown SNMP settings %q: expected v2, v3 or trap", args[0])
+	}
+}
+
+func snmpSet(e *env, args []string) error {
+	if len(args) < 1 {
+		return errors.New("usage: ruckusctl snmp set <v2|v3|trap> -f <file>")
+	}
+	which := args[0]
+	path, _, err := parseInputFlags("snmp set "+which, args[1:])
+	if err != nil {
+		return err
+	}
+	data, err := readInput(path)
+	if err != nil {
+		return err
+	}
+
+	// Start from the current settings so the file only needs to contain what changes
+	switch which {
+	case "v2":
+		settings, err := e.client.SNMP().GetV2(e.ctx)
+		if err != nil {
+			return err
+		}
+		if err := decodeInput(data, settings); err != nil {
+			return err
+		}
+		return e.client.SNMP().SetV2(e.ctx, *settings)
+
+	case "v3":
+		settings, err := e.client.SNMP().GetV3(e.ctx)
+		if err != nil {
+			return err
+		}
+		if err := decodeInput(data, settings); err != nil {
+			return err
+		}
+		return e.client.SNMP().SetV3(e.ctx, *settings)
+
+	case "trap":
+		settings, err := e.client.SNMP().GetTrap(e.ctx)
+		if err != nil {
+			return err
+		}
+		if err := decodeInput(data, settings); err != nil {
+			return err
+		}
+		return e.client.SNMP().SetTrap(e.ctx, *settings)
+
+	default:
+		return fmt.Errorf("unknown SNMP settings %q: expected v2, v3 or trap", which)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+func cmdStations(e *env, args []string) error {
+	return subcommand(e, "stations", args, map[string]command{
+		"list":       stationsList,
+		"rename":     stationsRename,
+		"favorite":   stationsFavorite,
+		"legacy":     stationsLegacy,
+		"roles":      stationsRoles,
+		"precedence": stationsPrecedence,
+	})
+}
+
+func stationsList(e *env, args []string) error {
+	fs := flag.NewFlagSet("stations list", flag.ContinueOnError)
+	wlan := fs.String("wlan", "", "only list stations on the named WLAN")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: ruckusctl stations list [-wlan <name>]")
+	}
+
+	var stations []ruckusweb.Station
+	var err error
+	if *wlan != "" {
+		stations, err = e.client.Stations().ListByWlanName(e.ctx, *wlan)
+	} else {
+		stations, err = e.client.Stations().List(e.ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"MAC", "HOSTNAME", "IP", "WLAN", "AP", "BAND", "RSSI", "DEVICE"}}
+	for _, station := range stations {
+		t.add(net.HardwareAddr(station.Mac), station.Hostname, station.Ip, station.Wlan, station.ApName, station.RadioBand, station.Rssi, station.DeviceInfo)
+	}
+	return e.format.print(e.stdout, stations, t)
+}
+
+func stationsRoles(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl stations roles")
+	}
+
+	stationRoles, err := e.client.Roles().Stations(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"MAC", "HOSTNAME", "USER", "WLAN", "VLAN", "ROLE", "GROUP ATTRIBUTE"}}
+	for _, sr := range stationRoles {
+		role, groupAttr := fmt.Sprintf("unknown (%d)", sr.Station.RoleID), ""
+		if sr.Role != nil {
+			role, groupAttr = sr.Role.Name, sr.Role.GroupAttr
+		}
+		t.add(net.HardwareAddr(sr.Station.Mac), sr.Station.Hostname, sr.Station.User, sr.Station.Wlan, sr.Station.Vlan, role, groupAttr)
+	}
+	return e.format.print(e.stdout, stationRoles, t)
+}
+
+func stationsPrecedence(e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: ruckusctl stations precedence <mac>")
+	}
+	mac, err := parseMac(args[0])
+	if err != nil {
+		return err
+	}
+
+	stations, err := e.client.Stations().List(e.ctx)
+	if err != nil {
+		return err
+	}
+	var station *ruckusweb.Station
+	for i := range stations {
+		if bytes.Equal(stations[i].Mac, mac) {
+			station = &stations[i]
+			break
+		}
+	}
+	if station == nil {
+		return fmt.Errorf("station %s is not connected", net.HardwareAddr(mac))
+	}
+
+	explained, err := e.client.PrecedencePolicies().Explain(e.ctx, *station)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"SETTING", "SOURCE", "VALUE", "DETAIL"}}
+	for _, setting := range []struct {
+		name     string
+		decision ruckusweb.PrecedenceDecision
+	}{
+		{"VLAN", explained.Vlan},
+		{"rate limit", explained.RateLimit},
+	} {
+		source, value := "none", ""
+		if setting.decision.Applied {
+			source, value = setting.decision.Source.String(), setting.decision.Value
+		}
+		for i, step := range setting.decision.Steps {
+			if i == 0 {
+				t.add(setting.name, source, value, step)
+			} else {
+				t.add("", "", "", step)
+			}
+		}
+	}
+	return e.format.print(e.stdout, explained, t)
+}
+
+func parseMac(s string) (ruckusweb.MacAddress, error) {
+	var mac ruckusweb.MacAddress
+	if err := mac.UnmarshalText([]byte(s)); err != nil {
+		return nil, err
+	}
+	return mac, nil
+}
+
+func parseOnOff(s string) (bool, error) {
+	switch s {
+	case "on", "true", "1":
+		return true, nil
+	case "off", "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected on or off, got %q", s)
+	}
+}
+
+func stationsRename(e *env, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: ruckusctl stations rename <mac> <name>\n\nAn empty name forgets the station.")
+	}
+	mac, err := parseMac(args[0])
+	if err != nil {
+		return err
+	}
+	return e.client.Stations().SetName(e.ctx, mac, args[1])
+}
+
+func stationsFavorite(e *env, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: ruckusctl stations favorite <mac> <on|off>")
+	}
+	mac, err := parseMac(args[0])
+	if err != nil {
+		return err
+	}
+	favorite, err := parseOnOff(args[1])
+	if err != nil {
+		return err
+	}
+	return e.client.Stations().SetFavorite(e.ctx, mac, favorite)
+}
+
+func stationsLegacy(e *env, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: ruckusctl stations legacy <mac> <on|off>")
+	}
+	mac, err := parseMac(args[0])
+	if err != nil {
+		return err
+	}
+	legacy, err := parseOnOff(args[1])
+	if err != nil {
+		return err
+	}
+	return e.client.Stations().SetLegacy(e.ctx, mac, legacy)
+}
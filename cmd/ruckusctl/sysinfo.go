@@ -0,0 +1,20 @@
+package main
+
+import (
+	"errors"
+)
+
+func cmdSysinfo(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl sysinfo")
+	}
+
+	info, err := e.client.Sysinfo(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"MODEL", "SERIAL", "VERSION", "BUILD", "UPTIME", "MAX APS"}}
+	t.add(info.Model, info.Serial, info.Version, info.BuildNum, info.Uptime, info.Maxap)
+	return e.format.print(e.stdout, info, t)
+}
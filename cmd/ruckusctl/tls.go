@@ -0,0 +1,128 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+func cmdTLS(e *env, args []string) error {
+	return subcommand(e, "tls", args, map[string]command{
+		"get-certs": tlsGetCerts,
+		"csr":       tlsCSR,
+		"set-certs": tlsSetCerts,
+		"regen-key": tlsRegenKey,
+	})
+}
+
+// tlsGetCerts writes the device's certificate chain as PEM.
+func tlsGetCerts(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl tls get-certs")
+	}
+	certs, err := e.client.TLS().GetCertificates(e.ctx)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		if err := pem.Encode(e.stdout, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tlsCSR writes a certificate signing request for the device's private key as PEM.
+func tlsCSR(e *env, args []string) error {
+	fs := flag.NewFlagSet("tls csr", flag.ContinueOnError)
+	var input ruckusweb.CsrInput
+	fs.StringVar(&input.CN, "cn", "", "common name")
+	fs.StringVar(&input.OU, "ou", "", "organizational unit")
+	fs.StringVar(&input.O, "o", "", "organization")
+	fs.StringVar(&input.L, "l", "", "locality")
+	fs.StringVar(&input.S, "s", "", "state")
+	fs.StringVar(&input.C, "c", "", "country")
+	fs.StringVar(&input.SanDNS, "san-dns", "", "DNS subject alternative name")
+	sanIP := fs.String("san-ip", "", "IP subject alternative name, ignored if -san-dns is set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if input.CN == "" || fs.NArg() != 0 {
+		return errors.New("usage: ruckusctl tls csr -cn <name> [-ou ...] [-o ...] [-l ...] [-s ...] [-c ...] [-san-dns ...|-san-ip ...]")
+	}
+	if *sanIP != "" {
+		if input.SanIP = net.ParseIP(*sanIP); input.SanIP == nil {
+			return fmt.Errorf("invalid IP %q", *sanIP)
+		}
+	}
+
+	csr, err := e.client.TLS().GetCertificateRequest(e.ctx, input)
+	if err != nil {
+		return err
+	}
+	return pem.Encode(e.stdout, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr.Raw})
+}
+
+// tlsSetCerts uploads a PEM certificate chain, leaf first.
+func tlsSetCerts(e *env, args []string) error {
+	path, _, err := parseInputFlags("tls set-certs", args)
+	if err != nil {
+		return err
+	}
+	data, err := readInput(path)
+	if err != nil {
+		return err
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return errors.New("no certificates found")
+	}
+
+	if e.dryRun {
+		// Certificates are uploaded rather than sent as XML
+		for _, cert := range certs {
+			fmt.Fprintf(e.stderr, "would upload certificate %q issued by %q\n", cert.Subject, cert.Issuer)
+		}
+		return nil
+	}
+	return e.client.TLS().SetCertificates(e.ctx, certs)
+}
+
+// tlsRegenKey asks the device to generate a new private key, which reboots it.
+func tlsRegenKey(e *env, args []string) error {
+	fs := flag.NewFlagSet("tls regen-key", flag.ContinueOnError)
+	bits := fs.Int("bits", 2048, "key size: 1024 or 2048")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if (*bits != 1024 && *bits != 2048) || fs.NArg() != 0 {
+		return errors.New("usage: ruckusctl tls regen-key [-bits 1024|2048]")
+	}
+
+	if e.dryRun {
+		fmt.Fprintf(e.stderr, "would generate a new %d-bit private key and reboot\n", *bits)
+		return nil
+	}
+	return e.client.TLS().SetPrivateKey(e.ctx, *bits == 2048)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+func cmdUsers(e *env, args []string) error {
+	return subcommand(e, "users", args, map[string]command{
+		"list":   usersList,
+		"import": usersImport,
+		"export": usersExport,
+	})
+}
+
+func usersList(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl users list")
+	}
+	users, err := e.client.Users().List(e.ctx)
+	if err != nil {
+		return err
+	}
+	roles, err := e.client.Roles().List(e.ctx)
+	if err != nil {
+		return err
+	}
+	roleNames := make(map[int]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+	}
+
+	t := &table{headers: []string{"ID", "USERNAME", "FULL NAME", "ROLE"}}
+	for _, user := range users {
+		t.add(user.ID, user.Name, user.FullName, roleNames[user.RoleID])
+	}
+	return e.format.print(e.stdout, users, t)
+}
+
+func usersImport(e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: ruckusctl users import <users.csv>")
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	created, updated, err := e.client.Users().ImportCSV(e.ctx, f)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "%d users created, %d updated\n", created, updated)
+	return nil
+}
+
+func usersExport(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl users export")
+	}
+	return e.client.Users().ExportCSV(e.ctx, e.stdout)
+}
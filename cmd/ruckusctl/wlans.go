@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+// parseInputFlags parses a subcommand's flags, requiring -f.
+func parseInputFlags(name string, args []string) (string, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := fs.String("f", "", "JSON or YAML file to read, or - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return "", nil, err
+	}
+	if *path == "" {
+		return "", nil, fmt.Errorf("usage: ruckusctl %s -f <file>", name)
+	}
+	return *path, fs.Args(), nil
+}
+
+func cmdWlans(e *env, args []string) error {
+	return subcommand(e, "wlans", args, map[string]command{
+		"list":   wlansList,
+		"create": wlansCreate,
+		"update": wlansUpdate,
+		"delete": wlansDelete,
+	})
+}
+
+func wlansTable(wlans []ruckusweb.Wlan) *table {
+	t := &table{headers: []string{"ID", "NAME", "SSID", "ENCRYPTION", "VLAN", "GUEST", "DESCRIPTION"}}
+	for _, wlan := range wlans {
+		encryption, _ := wlan.Encryption.MarshalText()
+		t.add(wlan.ID, wlan.Name, wlan.Ssid, string(encryption), wlan.VlanID, wlan.IsGuest, wlan.Description)
+	}
+	return t
+}
+
+func wlansList(e *env, args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: ruckusctl wlans list")
+	}
+	wlans, err := e.client.Wlans().List(e.ctx)
+	if err != nil {
+		return err
+	}
+	return e.format.print(e.stdout, wlans, wlansTable(wlans))
+}
+
+func wlansCreate(e *env, args []string) error {
+	path, _, err := parseInputFlags("wlans create", args)
+	if err != nil {
+		return err
+	}
+
+	data, err := readInput(path)
+	if err != nil {
+		return err
+	}
+	var input ruckusweb.Wlan
+	if err := decodeInput(data, &input); err != nil {
+		return err
+	}
+
+	// Fill in defaults for anything the file leaves out
+	wlan := ruckusweb.NewWlan(input.Name)
+	if err := decodeInput(data, &wlan); err != nil {
+		return err
+	}
+
+	created, err := e.client.Wlans().Create(e.ctx, wlan)
+	if err != nil || e.dryRun {
+		return err
+	}
+	return e.format.print(e.stdout, created, wlansTable([]ruckusweb.Wlan{*created}))
+}
+
+func wlansUpdate(e *env, args []string) error {
+	path, _, err := parseInputFlags("wlans update", args)
+	if err != nil {
+		return err
+	}
+
+	data, err := readInput(path)
+	if err != nil {
+		return err
+	}
+	var input ruckusweb.Wlan
+	if err := decodeInput(data, &input); err != nil {
+		return err
+	}
+
+	// Start from the current record so the file only needs to contain what changes
+	current, err := findWlan(e, input.Name, input.ID)
+	if err != nil {
+		return err
+	}
+	wlan := *current
+	if err := decodeInput(data, &wlan); err != nil {
+		return err
+	}
+	wlan.ID = current.ID
+
+	return e.client.Wlans().Update(e.ctx, wlan)
+}
+
+func wlansDelete(e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: ruckusctl wlans delete <id|name>")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		wlan, err := findWlan(e, args[0], 0)
+		if err != nil {
+			return err
+		}
+		id = wlan.ID
+	}
+
+	return e.client.Wlans().Delete(e.ctx, id)
+}
+
+// findWlan finds a WLAN by ID if id is non-zero, or otherwise by name.
+func findWlan(e *env, name string, id int) (*ruckusweb.Wlan, error) {
+	wlans, err := e.client.Wlans().List(e.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, wlan := range wlans {
+		if (id != 0 && wlan.ID == id) || (id == 0 && wlan.Name == name) {
+			return &wlan, nil
+		}
+	}
+	if id != 0 {
+		return nil, fmt.Errorf("no WLAN with ID %d", id)
+	}
+	return nil, fmt.Errorf("no WLAN named %q", name)
+}
@@ -3,6 +3,7 @@ package ruckusweb
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 )
 
 type AAA struct {
@@ -104,10 +105,52 @@ func (A AaaActiveDirectory) aaaserver() {
 
 var _ AaaServer = &AaaActiveDirectory{}
 
+// aaaServerElement decodes an authsvr element into the AaaServer implementation matching its type, and encodes an
+// AaaServer as an authsvr element.
+type aaaServerElement struct {
+	AaaServer
+}
+
+func (e *aaaServerElement) UnmarshalXML(d *xml.Decoder, se xml.StartElement) error {
+	var typ string
+	for _, attr := range se.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "type" {
+			typ = attr.Value
+			break
+		}
+	}
+
+	switch typ {
+	case "radius-auth", "radius-acct":
+		var server AaaRadius
+		if err := d.DecodeElement(&server, &se); err != nil {
+			return err
+		}
+		e.AaaServer = &server
+		return nil
+	case "ad":
+		var server AaaActiveDirectory
+		if err := d.DecodeElement(&server, &se); err != nil {
+			return err
+		}
+		e.AaaServer = &server
+		return nil
+	default:
+		// Unsupported server type
+		return d.Skip()
+	}
+}
+
+func (e aaaServerElement) MarshalXML(enc *xml.Encoder, se xml.StartElement) error {
+	se.Name = xml.Name{Local: "authsvr"}
+	return enc.EncodeElement(e.AaaServer, se)
+}
+
+// List returns the configured AAA servers as *AaaRadius or *AaaActiveDirectory. Servers of other types are omitted.
 func (a AAA) List(ctx context.Context) ([]AaaServer, error) {
 	var resp struct {
-		XMLName xml.Name   `xml:"authsvr-list"`
-		Authsvr []AaaEntry `xml:"authsvr"`
+		XMLName xml.Name           `xml:"authsvr-list"`
+		Authsvr []aaaServerElement `xml:"authsvr"`
 	}
 
 	if err := a.c.conf(ctx, confReq{
@@ -116,9 +159,75 @@ func (a AAA) List(ctx context.Context) ([]AaaServer, error) {
 		Comp:     "authsvr-list",
 	}, nil, &resp); err != nil {
 		return nil, err
+	}
+
+	servers := make([]AaaServer, 0, len(resp.Authsvr))
+	for _, e := range resp.Authsvr {
+		if e.AaaServer != nil {
+			servers = append(servers, e.AaaServer)
+		}
+	}
+	return servers, nil
+}
+
+// Create creates an AAA server, returning the created record. In dry run mode, it returns server with a zero ID.
+func (a AAA) Create(ctx context.Context, server AaaServer) (AaaServer, error) {
+	// ensure we don't specify an ID
+	switch s := server.(type) {
+	case *AaaRadius:
+		copied := *s
+		copied.AaaEntry.ID = 0
+		server = &copied
+	case AaaRadius:
+		s.AaaEntry.ID = 0
+		server = &s
+	case *AaaActiveDirectory:
+		copied := *s
+		copied.AaaEntry.ID = 0
+		server = &copied
+	case AaaActiveDirectory:
+		s.AaaEntry.ID = 0
+		server = &s
+	default:
+		return nil, errors.New("unsupported AaaServer type")
+	}
+	if server.Name() == "" {
+		return nil, errors.New("invalid AaaServer: name must be set")
+	}
+
+	var resp aaaServerElement
+	if err := a.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "authsvr-list",
+	}, aaaServerElement{server}, &resp); err != nil {
+		return nil, err
+	} else if resp.AaaServer != nil {
+		return resp.AaaServer, nil
+	} else if a.c.isDryRun() {
+		return server, nil
 	} else {
-		// TODO
-		return nil, nil
-		//return resp.Authsvr, nil
+		return nil, errors.New("could not decode the created AAA server")
 	}
 }
+
+// Update updates an AAA server, replacing the record.
+func (a AAA) Update(ctx context.Context, server AaaServer) error {
+	return a.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "authsvr-list",
+	}, aaaServerElement{server}, nil)
+}
+
+// Delete an AAA server by ID.
+func (a AAA) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"authsvr"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return a.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "authsvr-list",
+	}, &req, nil)
+}
@@ -0,0 +1,242 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type AdminPrivilege int
+
+const (
+	// AdminPrivilegeUnknown indicates the privilege level is not known, e.g. because the Client has not logged in yet.
+	AdminPrivilegeUnknown AdminPrivilege = iota
+	// AdminPrivilegeMonitor can view but not change anything.
+	AdminPrivilegeMonitor
+	// AdminPrivilegeOperator can change some settings, e.g. guest passes and clients, but not system configuration.
+	AdminPrivilegeOperator
+	// AdminPrivilegeSuper can change everything.
+	AdminPrivilegeSuper
+)
+
+// MarshalText encodes AdminPrivilegeUnknown as "", the value read from a missing attribute.
+func (p AdminPrivilege) MarshalText() ([]byte, error) {
+	switch p {
+	case AdminPrivilegeUnknown:
+		return []byte{}, nil
+	case AdminPrivilegeMonitor:
+		return []byte("monitor"), nil
+	case AdminPrivilegeOperator:
+		return []byte("operator"), nil
+	case AdminPrivilegeSuper:
+		return []byte("super"), nil
+	default:
+		return nil, fmt.Errorf("invalid AdminPrivilege: %d", int(p))
+	}
+}
+
+func (p *AdminPrivilege) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "":
+		*p = AdminPrivilegeUnknown
+	case "monitor":
+		*p = AdminPrivilegeMonitor
+	case "operator":
+		*p = AdminPrivilegeOperator
+	case "super":
+		*p = AdminPrivilegeSuper
+	default:
+		return fmt.Errorf("invalid admin privilege value: %q", string(text))
+	}
+	return nil
+}
+
+// CanWrite returns true if the privilege level permits configuration changes.
+func (p AdminPrivilege) CanWrite() bool {
+	return p == AdminPrivilegeOperator || p == AdminPrivilegeSuper
+}
+
+// Privilege returns the privilege level granted at the most recent login, or AdminPrivilegeUnknown if the Client has
+// not logged in yet.
+func (c *Client) Privilege() AdminPrivilege {
+	c.m.Lock()
+	privilege := c.loginResult.privilege
+	c.m.Unlock()
+
+	var p AdminPrivilege
+	if err := p.UnmarshalText([]byte(privilege)); err != nil {
+		return AdminPrivilegeUnknown
+	}
+	return p
+}
+
+type Admins struct {
+	c *Client
+}
+
+func (c *Client) Admins() Admins {
+	return Admins{c}
+}
+
+// AdminSettings describes the primary administrator account and how administrators authenticate.
+type AdminSettings struct {
+	Username  string `xml:"username,attr"`
+	XPassword string `xml:"x-password,attr"`
+
+	// AuthBy is "local" to authenticate administrators against the device only, or "external" to authenticate them
+	// against AuthsvrID.
+	AuthBy    string `xml:"auth-by,attr"`
+	AuthsvrID int    `xml:"authsvr-id,attr"`
+	// FallbackLocal allows the primary administrator to log in locally if AuthsvrID is unreachable.
+	FallbackLocal bool `xml:"fallback-local,attr"`
+}
+
+func (a Admins) GetSettings(ctx context.Context) (*AdminSettings, error) {
+	var req struct {
+		XMLName xml.Name `xml:"admin"`
+	}
+	var resp struct {
+		XMLName xml.Name      `xml:"resultset"`
+		Admin   AdminSettings `xml:"admin"`
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "true",
+		Comp:     "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Admin, nil
+	}
+}
+
+func (a Admins) SetSettings(ctx context.Context, settings AdminSettings) error {
+	req := struct {
+		XMLName xml.Name `xml:"admin"`
+		AdminSettings
+	}{AdminSettings: settings}
+
+	return a.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// SetPassword changes the primary administrator's password. If the Client is logged in as the primary administrator,
+// its Credentials are updated to match.
+func (a Admins) SetPassword(ctx context.Context, password string) error {
+	if len(password) == 0 {
+		return errors.New("admin password must be set")
+	}
+
+	settings, err := a.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+	settings.XPassword = password
+	if err := a.SetSettings(ctx, *settings); err != nil {
+		return err
+	}
+
+	a.c.m.Lock()
+	if a.c.credentials.Username == settings.Username {
+		a.c.credentials.Password = password
+	}
+	a.c.m.Unlock()
+
+	return nil
+}
+
+// SetAuthServer authenticates administrators against the AAA server authsvrID, optionally falling back to the local
+// primary administrator account if the server is unreachable. An authsvrID of 0 authenticates locally.
+func (a Admins) SetAuthServer(ctx context.Context, authsvrID int, fallbackLocal bool) error {
+	settings, err := a.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if authsvrID == 0 {
+		settings.AuthBy = "local"
+		settings.AuthsvrID = 0
+		settings.FallbackLocal = false
+	} else {
+		settings.AuthBy = "external"
+		settings.AuthsvrID = authsvrID
+		settings.FallbackLocal = fallbackLocal
+	}
+	return a.SetSettings(ctx, *settings)
+}
+
+// AdminAccount is an additional administrator account.
+type AdminAccount struct {
+	ID        int            `xml:"id,attr,omitempty"`
+	Name      string         `xml:"name,attr"`
+	XPassword string         `xml:"x-password,attr"`
+	Privilege AdminPrivilege `xml:"privilege,attr,omitempty"`
+}
+
+func (a Admins) List(ctx context.Context) ([]AdminAccount, error) {
+	var resp struct {
+		XMLName xml.Name       `xml:"admin-list"`
+		Admin   []AdminAccount `xml:"admin"`
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "admin-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Admin, nil
+	}
+}
+
+func (a Admins) Create(ctx context.Context, account AdminAccount) (*AdminAccount, error) {
+	var req struct {
+		XMLName xml.Name `xml:"admin"`
+		AdminAccount
+	}
+	req.AdminAccount = account
+	req.AdminAccount.ID = 0 // ensure we don't specify one
+
+	if len(account.Name) == 0 {
+		return nil, errors.New("invalid AdminAccount: name must be set")
+	}
+	if len(account.XPassword) == 0 {
+		return nil, errors.New("invalid AdminAccount: password must be set")
+	}
+	if account.Privilege == AdminPrivilegeUnknown {
+		return nil, errors.New("invalid AdminAccount: privilege must be set")
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"admin"`
+		AdminAccount
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "admin-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.AdminAccount, nil
+	}
+}
+
+// Delete an AdminAccount by ID.
+func (a Admins) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"admin"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return a.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "admin-list",
+	}, &req, nil)
+}
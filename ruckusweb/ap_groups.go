@@ -3,6 +3,7 @@ package ruckusweb
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 )
 
 type APGroups struct {
@@ -47,12 +48,15 @@ type APGroup struct {
 	} `xml:"lldp"`
 	//Models    string `xml:"models"`
 	Wlangroup struct {
-		Wlansvc []struct {
-			ID int `xml:"id,attr"`
-		} `xml:"wlansvc"`
+		Wlansvc []APGroupWlansvc `xml:"wlansvc"`
 	} `xml:"wlangroup"`
 }
 
+// APGroupWlansvc refers to a Wlan by ID.
+type APGroupWlansvc struct {
+	ID int `xml:"id,attr"`
+}
+
 type APGroupRadio struct {
 	RadioType            string  `xml:"radio-type,attr"`
 	Channel              string  `xml:"channel,attr"`
@@ -87,3 +91,60 @@ func (a APGroups) List(ctx context.Context) ([]APGroup, error) {
 		return resp.Apgroup, nil
 	}
 }
+
+// Create creates an APGroup, returning the created record.
+func (a APGroups) Create(ctx context.Context, group APGroup) (*APGroup, error) {
+	var req struct {
+		XMLName xml.Name `xml:"apgroup"`
+		APGroup
+	}
+	req.APGroup = group
+	req.APGroup.ID = 0 // ensure we don't specify one
+
+	if len(req.Name) == 0 {
+		return nil, errors.New("invalid APGroup: name must be set")
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"apgroup"`
+		APGroup
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "apgroup-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.APGroup, nil
+	}
+}
+
+// Update updates an APGroup, replacing the record.
+func (a APGroups) Update(ctx context.Context, group APGroup) error {
+	req := struct {
+		XMLName xml.Name `xml:"apgroup"`
+		APGroup
+	}{
+		APGroup: group,
+	}
+
+	return a.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "apgroup-list",
+	}, &req, nil)
+}
+
+// Delete an APGroup by ID. APs in the group move to the System Default group.
+func (a APGroups) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"apgroup"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return a.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "apgroup-list",
+	}, &req, nil)
+}
@@ -44,13 +44,8 @@ type APStatus struct {
 	TxPower11na        int        `xml:"tx-power-11na,attr"`
 	Channelization11na int        `xml:"channelization-11na,attr"`
 	PoeModeWarningCode string     `xml:"poe-mode-warning-code,attr"`
-	Radio              []struct {
-		RadioType      string `xml:"radio-type,attr"`
-		RadioBand      string `xml:"radio-band,attr"`
-		Channel        int    `xml:"channel,attr"`
-		Channelization int    `xml:"channelization,attr"`
-		DfsChannel11na string `xml:"dfs-channel-11na,attr"`
-	} `xml:"radio"`
+
+	Radio   []APStatusRadio `xml:"radio"`
 	History struct {
 		RxBytes24g ByteTimeSeries `xml:"rx-bytes-2.4g,attr"`
 		TxBytes24g ByteTimeSeries `xml:"tx-bytes-2.4g,attr"`
@@ -60,6 +55,14 @@ type APStatus struct {
 	} `xml:"history"`
 }
 
+type APStatusRadio struct {
+	RadioType      string `xml:"radio-type,attr"`
+	RadioBand      string `xml:"radio-band,attr"`
+	Channel        int    `xml:"channel,attr"`
+	Channelization int    `xml:"channelization,attr"`
+	DfsChannel11na string `xml:"dfs-channel-11na,attr"`
+}
+
 func (a APs) ListStatuses(ctx context.Context) ([]APStatus, error) {
 	var req struct {
 		XMLName xml.Name `xml:"ajax-request"`
@@ -181,3 +184,19 @@ func (a APs) List(ctx context.Context) ([]AP, error) {
 		return resp.Ap, nil
 	}
 }
+
+// Update updates an AP, replacing the record. APs are created by joining the network and cannot be created or
+// deleted here.
+func (a APs) Update(ctx context.Context, ap AP) error {
+	req := struct {
+		XMLName xml.Name `xml:"ap"`
+		AP
+	}{
+		AP: ap,
+	}
+
+	return a.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "ap-list",
+	}, &req, nil)
+}
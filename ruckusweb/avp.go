@@ -0,0 +1,290 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+)
+
+// AppPolicies manages application visibility and control (AVP) policies, which deny or rate-limit traffic by
+// application. Wlans refer to them by Wlan.AvpPolicy.AvpdenyID.
+type AppPolicies struct {
+	c *Client
+}
+
+func (c *Client) AppPolicies() AppPolicies {
+	return AppPolicies{c}
+}
+
+type AppRuleType int
+
+const (
+	// AppRuleTypeDeny blocks the application.
+	AppRuleTypeDeny AppRuleType = iota
+	// AppRuleTypeRateLimit limits each station's throughput for the application.
+	AppRuleTypeRateLimit
+)
+
+func (t AppRuleType) MarshalText() ([]byte, error) {
+	switch t {
+	case AppRuleTypeDeny:
+		return []byte("denial"), nil
+	case AppRuleTypeRateLimit:
+		return []byte("rate-limit"), nil
+	default:
+		return nil, fmt.Errorf("invalid AppRuleType %d", int(t))
+	}
+}
+
+func (t *AppRuleType) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "denial":
+		*t = AppRuleTypeDeny
+	case "rate-limit":
+		*t = AppRuleTypeRateLimit
+	default:
+		return fmt.Errorf("invalid application rule type: %q", string(text))
+	}
+	return nil
+}
+
+type AppPolicy struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	Rules []AppPolicyRule `xml:"rule"`
+}
+
+// AppPolicyRule matches an application by name, as reported in AppUsage.Application, or by destination port.
+type AppPolicyRule struct {
+	Type        AppRuleType `xml:"type,attr"`
+	Application string      `xml:"application,attr,omitempty"`
+	Port        int         `xml:"port,attr,omitempty"`
+
+	// UplinkKbps and DownlinkKbps are used by AppRuleTypeRateLimit. Zero leaves that direction unlimited.
+	UplinkKbps   int `xml:"uplink,attr,omitempty"`
+	DownlinkKbps int `xml:"downlink,attr,omitempty"`
+}
+
+func (p AppPolicy) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	for i, rule := range p.Rules {
+		if (rule.Application == "") == (rule.Port == 0) {
+			return fmt.Errorf("rule %d: exactly one of application and port must be set", i+1)
+		}
+		if rule.Port < 0 || rule.Port > 65535 {
+			return fmt.Errorf("rule %d: invalid port %d", i+1, rule.Port)
+		}
+		switch rule.Type {
+		case AppRuleTypeDeny:
+			if rule.UplinkKbps != 0 || rule.DownlinkKbps != 0 {
+				return fmt.Errorf("rule %d: rate limits require a rate-limit rule", i+1)
+			}
+		case AppRuleTypeRateLimit:
+			if rule.UplinkKbps <= 0 && rule.DownlinkKbps <= 0 {
+				return fmt.Errorf("rule %d: rate-limit rules require an uplink or downlink rate", i+1)
+			}
+		default:
+			return fmt.Errorf("rule %d: invalid type %d", i+1, int(rule.Type))
+		}
+	}
+	return nil
+}
+
+func (a AppPolicies) List(ctx context.Context) ([]AppPolicy, error) {
+	var resp struct {
+		XMLName xml.Name    `xml:"avpdeny-list"`
+		Avpdeny []AppPolicy `xml:"avpdeny"`
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "avpdeny-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Avpdeny, nil
+	}
+}
+
+// Create creates an AppPolicy, returning the created record.
+func (a AppPolicies) Create(ctx context.Context, policy AppPolicy) (*AppPolicy, error) {
+	var req struct {
+		XMLName xml.Name `xml:"avpdeny"`
+		AppPolicy
+	}
+	req.AppPolicy = policy
+	req.AppPolicy.ID = 0 // ensure we don't specify one
+
+	if err := req.AppPolicy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid AppPolicy: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"avpdeny"`
+		AppPolicy
+	}
+
+	if err := a.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "avpdeny-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.AppPolicy, nil
+	}
+}
+
+// Update updates an AppPolicy, replacing the record.
+func (a AppPolicies) Update(ctx context.Context, policy AppPolicy) error {
+	req := struct {
+		XMLName xml.Name `xml:"avpdeny"`
+		AppPolicy
+	}{
+		AppPolicy: policy,
+	}
+
+	if err := req.AppPolicy.validate(); err != nil {
+		return fmt.Errorf("invalid AppPolicy: %v", err)
+	}
+
+	return a.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "avpdeny-list",
+	}, &req, nil)
+}
+
+// Delete an AppPolicy by ID. It must not be in use by any Wlan.
+func (a AppPolicies) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"avpdeny"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return a.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "avpdeny-list",
+	}, &req, nil)
+}
+
+// Bind enables application control on the named Wlan using the AppPolicy with the given ID.
+func (a AppPolicies) Bind(ctx context.Context, id int, wlanName string) error {
+	return a.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.AvpPolicy.AvpEnabled && wlan.AvpPolicy.AvpdenyID == id {
+			return false
+		}
+		wlan.AvpPolicy.AvpEnabled = true
+		wlan.AvpPolicy.AvpdenyID = id
+		return true
+	})
+}
+
+// Unbind disables application control on the named Wlan.
+func (a AppPolicies) Unbind(ctx context.Context, wlanName string) error {
+	return a.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if !wlan.AvpPolicy.AvpEnabled && wlan.AvpPolicy.AvpdenyID == 0 {
+			return false
+		}
+		wlan.AvpPolicy.AvpEnabled = false
+		wlan.AvpPolicy.AvpdenyID = 0
+		return true
+	})
+}
+
+// AppUsage is the traffic of one application seen by application visibility.
+type AppUsage struct {
+	Application string `xml:"name,attr"`
+	Category    string `xml:"category,attr"`
+	// UplinkBytes were sent by stations, and DownlinkBytes were sent to them.
+	UplinkBytes   uint64 `xml:"rx-bytes,attr"`
+	DownlinkBytes uint64 `xml:"tx-bytes,attr"`
+}
+
+func (u AppUsage) TotalBytes() uint64 {
+	return u.UplinkBytes + u.DownlinkBytes
+}
+
+// AppStats reports application visibility statistics. Application visibility must be enabled on a Wlan for its
+// traffic to be counted.
+type AppStats struct {
+	c *Client
+}
+
+func (c *Client) AppStats() AppStats {
+	return AppStats{c}
+}
+
+// Top returns the limit applications with the most traffic across the whole network, busiest first. A limit of 0
+// returns every application.
+func (a AppStats) Top(ctx context.Context, limit int) ([]AppUsage, error) {
+	return a.list(ctx, nil, limit)
+}
+
+// ByStation returns the limit applications with the most traffic to and from one station, busiest first.
+func (a AppStats) ByStation(ctx context.Context, mac MacAddress, limit int) ([]AppUsage, error) {
+	return a.list(ctx, []xml.Attr{{Name: xml.Name{Local: "client"}, Value: net.HardwareAddr(mac).String()}}, limit)
+}
+
+// ByAP returns the limit applications with the most traffic through one AP, busiest first.
+func (a AppStats) ByAP(ctx context.Context, mac MacAddress, limit int) ([]AppUsage, error) {
+	return a.list(ctx, []xml.Attr{{Name: xml.Name{Local: "ap"}, Value: net.HardwareAddr(mac).String()}}, limit)
+}
+
+// ByWlan returns the limit applications with the most traffic on the named Wlan, busiest first.
+func (a AppStats) ByWlan(ctx context.Context, name string, limit int) ([]AppUsage, error) {
+	return a.list(ctx, []xml.Attr{{Name: xml.Name{Local: "wlan"}, Value: name}}, limit)
+}
+
+func (a AppStats) list(ctx context.Context, scope []xml.Attr, limit int) ([]AppUsage, error) {
+	var req struct {
+		XMLName xml.Name    `xml:"ajax-request"`
+		Action  string      `xml:"action,attr"`
+		Updater string      `xml:"updater,attr"`
+		Comp    string      `xml:"comp,attr"`
+		Avp     attrElement `xml:"avp-stats"`
+	}
+	req.Action = "getstat"
+	req.Comp = "stamgr"
+	req.Avp.Attrs = scope
+	if limit > 0 {
+		req.Avp.Attrs = append(req.Avp.Attrs, xml.Attr{Name: xml.Name{Local: "top"}, Value: strconv.Itoa(limit)})
+	}
+
+	var resp struct {
+		XMLName  xml.Name `xml:"ajax-response"`
+		Response struct {
+			Type     string `xml:"type,attr"`
+			ID       string `xml:"id,attr"`
+			AvpStats struct {
+				App []AppUsage `xml:"app"`
+			} `xml:"avp-stats"`
+		} `xml:"response"`
+	}
+
+	if err := a.c.cmdstat(ctx, &req, &resp); err != nil {
+		return nil, err
+	}
+
+	return rankAppUsage(resp.Response.AvpStats.App, limit), nil
+}
+
+// rankAppUsage sorts apps busiest first and keeps the first limit, or all of them if limit is 0. The device's own
+// order isn't trusted, and it may return every application despite "top".
+func rankAppUsage(apps []AppUsage, limit int) []AppUsage {
+	sort.SliceStable(apps, func(i, j int) bool {
+		return apps[i].TotalBytes() > apps[j].TotalBytes()
+	})
+	if limit > 0 && len(apps) > limit {
+		apps = apps[:limit]
+	}
+	return apps
+}
@@ -0,0 +1,117 @@
+package ruckusweb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []AppPolicyRule
+		want  string
+	}{
+		{
+			"empty",
+			nil,
+			"",
+		},
+		{
+			"valid",
+			[]AppPolicyRule{
+				{Type: AppRuleTypeDeny, Application: "BitTorrent"},
+				{Type: AppRuleTypeDeny, Port: 6881},
+				{Type: AppRuleTypeRateLimit, Application: "YouTube", DownlinkKbps: 2000},
+			},
+			"",
+		},
+		{
+			"neither application nor port",
+			[]AppPolicyRule{{Type: AppRuleTypeDeny}},
+			"rule 1: exactly one of application and port must be set",
+		},
+		{
+			"both application and port",
+			[]AppPolicyRule{{Type: AppRuleTypeDeny, Application: "Netflix", Port: 443}},
+			"rule 1: exactly one of application and port must be set",
+		},
+		{
+			"port out of range",
+			[]AppPolicyRule{{Type: AppRuleTypeDeny, Port: 65536}},
+			"rule 1: invalid port 65536",
+		},
+		{
+			"negative port",
+			[]AppPolicyRule{{Type: AppRuleTypeDeny, Port: -1}},
+			"rule 1: invalid port -1",
+		},
+		{
+			"deny with rate",
+			[]AppPolicyRule{{Type: AppRuleTypeDeny, Application: "Netflix", UplinkKbps: 100}},
+			"rule 1: rate limits require a rate-limit rule",
+		},
+		{
+			"rate limit without rate",
+			[]AppPolicyRule{
+				{Type: AppRuleTypeDeny, Application: "Netflix"},
+				{Type: AppRuleTypeRateLimit, Application: "YouTube"},
+			},
+			"rule 2: rate-limit rules require an uplink or downlink rate",
+		},
+		{
+			"unknown type",
+			[]AppPolicyRule{{Type: AppRuleType(5), Application: "Netflix"}},
+			"rule 1: invalid type 5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AppPolicy{Name: "test", Rules: tt.rules}.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppRuleTypeMarshalText(t *testing.T) {
+	_, err := json.Marshal(AppPolicyRule{Type: AppRuleType(5)})
+	assert.ErrorContains(t, err, "invalid AppRuleType 5")
+}
+
+func TestRankAppUsage(t *testing.T) {
+	apps := func() []AppUsage {
+		return []AppUsage{
+			{Application: "DNS", UplinkBytes: 10, DownlinkBytes: 10},
+			{Application: "YouTube", UplinkBytes: 100, DownlinkBytes: 5000},
+			{Application: "HTTPS", UplinkBytes: 2000, DownlinkBytes: 1000},
+			{Application: "NTP", UplinkBytes: 15, DownlinkBytes: 5},
+		}
+	}
+	names := func(apps []AppUsage) []string {
+		var out []string
+		for _, app := range apps {
+			out = append(out, app.Application)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		want  []string
+	}{
+		{"all", 0, []string{"YouTube", "HTTPS", "DNS", "NTP"}},
+		{"top two", 2, []string{"YouTube", "HTTPS"}},
+		{"limit above count", 10, []string{"YouTube", "HTTPS", "DNS", "NTP"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, names(rankAppUsage(apps(), tt.limit)))
+		})
+	}
+}
@@ -22,6 +22,11 @@ type Client struct {
 	host        string
 	credentials Credentials
 	loginResult loginResult
+	dryRun      io.Writer
+	traceLog    *log.Logger
+
+	// editM serializes read-modify-write helpers like L2ACLs.AddMac
+	editM sync.Mutex
 }
 
 type Credentials struct {
@@ -42,6 +47,7 @@ func NewClient(Transport http.RoundTripper, host string, credentials Credentials
 
 		host:        host,
 		credentials: credentials,
+		traceLog:    log.Default(),
 	}
 
 	// Update the client host as we redirect
@@ -62,6 +68,64 @@ func NewClient(Transport http.RoundTripper, host string, credentials Credentials
 	return client
 }
 
+// Host returns the host the Client is currently talking to, which changes if the device redirects us elsewhere.
+func (c *Client) Host() string {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.host
+}
+
+// SetDryRun makes the Client write each configuration change to w as XML instead of sending it to the device. Reads
+// are still sent, so that callers can fetch the state they intend to change. Changes report success without a
+// response, e.g. Wlans.Create returns a zero Wlan. Passing nil resumes sending changes.
+//
+// Uploads, like TLS.SetCertificates, are not affected.
+func (c *Client) SetDryRun(w io.Writer) {
+	c.m.Lock()
+	c.dryRun = w
+	c.m.Unlock()
+}
+
+// SetTraceLog makes the Client log every XML request and response to l, which defaults to the standard logger.
+// Passing nil stops the logging.
+func (c *Client) SetTraceLog(l *log.Logger) {
+	c.m.Lock()
+	c.traceLog = l
+	c.m.Unlock()
+}
+
+func (c *Client) tracef(format string, v ...any) {
+	c.m.Lock()
+	l := c.traceLog
+	c.m.Unlock()
+	if l != nil {
+		l.Printf(format, v...)
+	}
+}
+
+func (c *Client) isDryRun() bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.dryRun != nil
+}
+
+// writeDryRun writes request to the dry run writer, returning false if dry run mode is off.
+func (c *Client) writeDryRun(path string, request any) (bool, error) {
+	c.m.Lock()
+	w := c.dryRun
+	c.m.Unlock()
+	if w == nil {
+		return false, nil
+	}
+
+	reqBody, err := xml.Marshal(request)
+	if err != nil {
+		return true, err
+	}
+	_, err = fmt.Fprintf(w, "POST %s\n%s\n", path, reqBody)
+	return true, err
+}
+
 func (c *Client) newRequestWithContext(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
 	c.m.Lock()
 	defer c.m.Unlock()
@@ -97,7 +161,7 @@ func (c *Client) postXml(ctx context.Context, path string, request interface{},
 	if err != nil {
 		return err
 	}
-	log.Printf("xml request: %s", string(reqBody))
+	c.tracef("xml request: %s", string(reqBody))
 
 	req, err := c.newRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(reqBody))
 	if err != nil {
@@ -118,7 +182,7 @@ func (c *Client) postXml(ctx context.Context, path string, request interface{},
 	if err != nil && err != io.EOF {
 		return err
 	}
-	log.Printf("xml response: %s", string(data))
+	c.tracef("xml response: %s", string(data))
 
 	return xml.NewDecoder(bytes.NewReader(data)).Decode(response)
 }
@@ -166,6 +230,12 @@ func (c *Client) conf(ctx context.Context, request confReq, requestPayload any,
 		} `xml:"response"`
 	}
 
+	if request.Action != "getconf" {
+		if dryRun, err := c.writeDryRun("/admin/_conf.jsp", &req); dryRun {
+			return err
+		}
+	}
+
 	// HTTP error?
 	if err := c.postXml(ctx, "/admin/_conf.jsp", &req, &resp); err != nil {
 		return err
@@ -185,6 +255,42 @@ func (c *Client) conf(ctx context.Context, request confReq, requestPayload any,
 	}
 }
 
+// docmd issues an xcmd against comp, failing if the device replies with an xmsg.
+func (c *Client) docmd(ctx context.Context, comp string, xcmd any) error {
+	req := struct {
+		XMLName  xml.Name `xml:"ajax-request"`
+		Action   string   `xml:"action,attr"`
+		AttrXcmd string   `xml:"xcmd,attr"`
+		Updater  string   `xml:"updater,attr"`
+		Comp     string   `xml:"comp,attr"`
+		Xcmd     any      `xml:"xcmd"`
+	}{
+		Action:   "docmd",
+		AttrXcmd: comp,
+		Comp:     comp,
+		Xcmd:     xcmd,
+	}
+
+	// Some commands answer with an ajax-response and others echo the ajax-request, so accept either
+	var resp struct {
+		Response struct {
+			Xmsg *xmsg `xml:"xmsg"`
+		} `xml:"response"`
+	}
+
+	if dryRun, err := c.writeDryRun("/admin/_cmdstat.jsp", &req); dryRun {
+		return err
+	}
+
+	if err := c.cmdstat(ctx, &req, &resp); err != nil {
+		return err
+	}
+	if resp.Response.Xmsg != nil {
+		return resp.Response.Xmsg
+	}
+	return nil
+}
+
 type xmsg struct {
 	Type string `xml:"type,attr"`
 	Msg  string `xml:"msg,attr"`
@@ -0,0 +1,240 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxDevicePolicyRules is the maximum number of rules in a DevicePolicy.
+const MaxDevicePolicyRules = 32
+
+type DevicePolicies struct {
+	c *Client
+}
+
+func (c *Client) DevicePolicies() DevicePolicies {
+	return DevicePolicies{c}
+}
+
+type DevicePolicyAction int
+
+const (
+	DevicePolicyActionAllow DevicePolicyAction = iota
+	DevicePolicyActionDeny
+)
+
+func (a DevicePolicyAction) MarshalText() ([]byte, error) {
+	switch a {
+	case DevicePolicyActionAllow:
+		return []byte("allow"), nil
+	case DevicePolicyActionDeny:
+		return []byte("deny"), nil
+	default:
+		return nil, fmt.Errorf("invalid DevicePolicyAction %d", int(a))
+	}
+}
+
+func (a DevicePolicyAction) valid() bool {
+	return a == DevicePolicyActionAllow || a == DevicePolicyActionDeny
+}
+
+func (a *DevicePolicyAction) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "allow":
+		*a = DevicePolicyActionAllow
+	case "deny":
+		*a = DevicePolicyActionDeny
+	default:
+		return fmt.Errorf("invalid device policy action: %q", string(text))
+	}
+	return nil
+}
+
+// DevicePolicy admits, blocks, rate-limits or re-VLANs stations according to their fingerprinted operating system and
+// device type. Wlans refer to it by Wlan.DevicepolicyID.
+type DevicePolicy struct {
+	ID          int                `xml:"id,attr,omitempty"`
+	Name        string             `xml:"name,attr"`
+	Description string             `xml:"description,attr"`
+	DefaultMode DevicePolicyAction `xml:"default-mode,attr"`
+
+	Rules []DevicePolicyRule `xml:"rule"`
+}
+
+// DevicePolicyRule applies to stations whose fingerprint matches OsType and DeviceType.
+type DevicePolicyRule struct {
+	// Order is the rule's 1-based position. Rules are evaluated in order and the first match wins.
+	Order       int    `xml:"order,attr"`
+	Description string `xml:"description,attr"`
+
+	// OsType is matched against Station.DeviceInfo, e.g. "Windows" or "Apple iOS". "All" or empty matches any station.
+	OsType string `xml:"devinfo,attr"`
+	// DeviceType is matched against Station.DeviceType, e.g. "Laptop" or "Smartphone". Empty matches any station.
+	DeviceType string `xml:"devtype,attr"`
+
+	Action DevicePolicyAction `xml:"action,attr"`
+	// Vlan moves allowed stations to another VLAN, or 0 to leave them on the Wlan's VLAN.
+	Vlan int `xml:"vlan,attr,omitempty"`
+	// UplinkKbps and DownlinkKbps limit allowed stations' throughput, or 0 for no limit.
+	UplinkKbps   int `xml:"uplink,attr,omitempty"`
+	DownlinkKbps int `xml:"downlink,attr,omitempty"`
+}
+
+// Matches returns true if the rule applies to station.
+func (r DevicePolicyRule) Matches(station Station) bool {
+	if r.OsType != "" && !strings.EqualFold(r.OsType, "All") && !fingerprintMatches(station.DeviceInfo, r.OsType) {
+		return false
+	}
+	if r.DeviceType != "" && !strings.EqualFold(station.DeviceType, r.DeviceType) {
+		return false
+	}
+	return true
+}
+
+// fingerprintMatches returns true if value is want, or want followed by a version, e.g. "Windows 10" for "Windows".
+func fingerprintMatches(value, want string) bool {
+	if len(value) < len(want) || !strings.EqualFold(value[:len(want)], want) {
+		return false
+	}
+	return len(value) == len(want) || value[len(want)] == ' '
+}
+
+// Match returns the rule which applies to station, or nil if the station gets the DefaultMode.
+func (p DevicePolicy) Match(station Station) *DevicePolicyRule {
+	for i := range p.Rules {
+		if p.Rules[i].Matches(station) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+func (p DevicePolicy) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if !p.DefaultMode.valid() {
+		return fmt.Errorf("invalid default mode %d", int(p.DefaultMode))
+	}
+	if len(p.Rules) > MaxDevicePolicyRules {
+		return fmt.Errorf("too many rules: %d > %d", len(p.Rules), MaxDevicePolicyRules)
+	}
+	for i, rule := range p.Rules {
+		if rule.Order != i+1 {
+			return fmt.Errorf("rule %d has order %d; rules must be numbered consecutively from 1", i+1, rule.Order)
+		}
+		if !rule.Action.valid() {
+			return fmt.Errorf("rule %d: invalid action %d", rule.Order, int(rule.Action))
+		}
+		if rule.Vlan < 0 || rule.Vlan > 4094 {
+			return fmt.Errorf("rule %d: invalid VLAN %d", rule.Order, rule.Vlan)
+		}
+		if rule.Action == DevicePolicyActionDeny && (rule.Vlan != 0 || rule.UplinkKbps != 0 || rule.DownlinkKbps != 0) {
+			return fmt.Errorf("rule %d: VLAN and rate limits only apply to allowed stations", rule.Order)
+		}
+	}
+	return nil
+}
+
+func (d DevicePolicies) List(ctx context.Context) ([]DevicePolicy, error) {
+	var resp struct {
+		XMLName      xml.Name       `xml:"devicepolicy-list"`
+		Devicepolicy []DevicePolicy `xml:"devicepolicy"`
+	}
+
+	if err := d.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "devicepolicy-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Devicepolicy, nil
+	}
+}
+
+// Create creates a DevicePolicy, returning the created record.
+func (d DevicePolicies) Create(ctx context.Context, policy DevicePolicy) (*DevicePolicy, error) {
+	var req struct {
+		XMLName xml.Name `xml:"devicepolicy"`
+		DevicePolicy
+	}
+	req.DevicePolicy = policy
+	req.DevicePolicy.ID = 0 // ensure we don't specify one
+
+	if err := req.DevicePolicy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid DevicePolicy: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"devicepolicy"`
+		DevicePolicy
+	}
+
+	if err := d.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "devicepolicy-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.DevicePolicy, nil
+	}
+}
+
+// Update updates a DevicePolicy, replacing the record.
+func (d DevicePolicies) Update(ctx context.Context, policy DevicePolicy) error {
+	req := struct {
+		XMLName xml.Name `xml:"devicepolicy"`
+		DevicePolicy
+	}{
+		DevicePolicy: policy,
+	}
+
+	if err := req.DevicePolicy.validate(); err != nil {
+		return fmt.Errorf("invalid DevicePolicy: %v", err)
+	}
+
+	return d.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "devicepolicy-list",
+	}, &req, nil)
+}
+
+// Delete a DevicePolicy by ID. It must not be in use by any Wlan.
+func (d DevicePolicies) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"devicepolicy"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return d.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "devicepolicy-list",
+	}, &req, nil)
+}
+
+// DevicePolicyMatch is a station and the rule of a DevicePolicy which would apply to it.
+type DevicePolicyMatch struct {
+	Station Station
+	// Rule is nil if no rule matches and the station would get the policy's DefaultMode.
+	Rule *DevicePolicyRule
+}
+
+// Preview reports how policy would treat each currently connected station, without changing anything. The policy
+// need not have been created.
+func (d DevicePolicies) Preview(ctx context.Context, policy DevicePolicy) ([]DevicePolicyMatch, error) {
+	stations, err := d.c.Stations().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]DevicePolicyMatch, len(stations))
+	for i, station := range stations {
+		matches[i] = DevicePolicyMatch{Station: station, Rule: policy.Match(station)}
+	}
+	return matches, nil
+}
@@ -0,0 +1,97 @@
+package ruckusweb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevicePolicyMatch(t *testing.T) {
+	policy := DevicePolicy{
+		Name: "test",
+		Rules: []DevicePolicyRule{
+			{Order: 1, OsType: "Gaming", Action: DevicePolicyActionDeny},
+			{Order: 2, OsType: "Windows", DeviceType: "Laptop", Action: DevicePolicyActionAllow, Vlan: 20},
+			{Order: 3, OsType: "All", DeviceType: "Smartphone", Action: DevicePolicyActionAllow, DownlinkKbps: 5000},
+		},
+	}
+	assert.NoError(t, policy.validate())
+
+	tests := []struct {
+		name    string
+		station Station
+		want    int
+	}{
+		{"gaming", Station{DeviceInfo: "Gaming", DeviceType: "Console"}, 1},
+		{"windows laptop", Station{DeviceInfo: "Windows 10", DeviceType: "Laptop"}, 2},
+		{"windows desktop", Station{DeviceInfo: "Windows 10", DeviceType: "Desktop"}, 0},
+		{"prefix is not a match", Station{DeviceInfo: "WindowsPhone", DeviceType: "Laptop"}, 0},
+		{"any smartphone", Station{DeviceInfo: "Android", DeviceType: "smartphone"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := policy.Match(tt.station)
+			if tt.want == 0 {
+				assert.Nil(t, rule)
+			} else if assert.NotNil(t, rule) {
+				assert.Equal(t, tt.want, rule.Order)
+			}
+		})
+	}
+}
+
+func TestDevicePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy DevicePolicy
+		want   string
+	}{
+		{
+			"valid",
+			DevicePolicy{Name: "p", DefaultMode: DevicePolicyActionDeny, Rules: []DevicePolicyRule{
+				{Order: 1, OsType: "Windows", Action: DevicePolicyActionAllow, Vlan: 20, UplinkKbps: 1000},
+			}},
+			"",
+		},
+		{
+			"invalid default mode",
+			DevicePolicy{Name: "p", DefaultMode: DevicePolicyAction(2)},
+			"invalid default mode 2",
+		},
+		{
+			"invalid rule action",
+			DevicePolicy{Name: "p", Rules: []DevicePolicyRule{{Order: 1, Action: DevicePolicyAction(-1)}}},
+			"rule 1: invalid action -1",
+		},
+		{
+			"misordered",
+			DevicePolicy{Name: "p", Rules: []DevicePolicyRule{{Order: 2}}},
+			"rule 1 has order 2; rules must be numbered consecutively from 1",
+		},
+		{
+			"rate limit on deny",
+			DevicePolicy{Name: "p", Rules: []DevicePolicyRule{{Order: 1, Action: DevicePolicyActionDeny, DownlinkKbps: 1000}}},
+			"rule 1: VLAN and rate limits only apply to allowed stations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDevicePolicyActionMarshalText(t *testing.T) {
+	text, err := DevicePolicyActionDeny.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "deny", string(text))
+
+	_, err = json.Marshal(DevicePolicyRule{Order: 1, Action: DevicePolicyAction(5)})
+	assert.ErrorContains(t, err, "invalid DevicePolicyAction 5")
+}
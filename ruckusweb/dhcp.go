@@ -0,0 +1,257 @@
+package ruckusweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// DHCPPools manages the pools of Unleashed's built-in DHCP server, which serve addresses on sites without a router
+// running DHCP. Each pool serves one VLAN. Wlans may also refer to a pool directly by Wlan.DhcpsvrID.
+type DHCPPools struct {
+	c *Client
+}
+
+func (c *Client) DHCPPools() DHCPPools {
+	return DHCPPools{c}
+}
+
+type DHCPPool struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	Enabled     bool   `xml:"enabled,attr"`
+	// Vlan is the VLAN on which the pool serves addresses.
+	Vlan int `xml:"vlan,attr"`
+
+	// Subnet and Netmask describe the network, e.g. 192.168.10.0 and 255.255.255.0.
+	Subnet  net.IP `xml:"subnet,attr"`
+	Netmask net.IP `xml:"netmask,attr"`
+	// StartIp and EndIp bound the addresses handed out, inclusive.
+	StartIp net.IP `xml:"start-ip,attr"`
+	EndIp   net.IP `xml:"end-ip,attr"`
+	// LeaseTime is in seconds.
+	LeaseTime int `xml:"lease-time,attr"`
+
+	// Gateway is the router offered to clients. It may be empty on networks which only need local connectivity.
+	Gateway net.IP `xml:"gateway,attr,omitempty"`
+	Dns1    net.IP `xml:"dns1,attr,omitempty"`
+	Dns2    net.IP `xml:"dns2,attr,omitempty"`
+
+	Options []DHCPOption `xml:"option"`
+}
+
+// DHCPOption is an additional DHCP option sent to clients, e.g. code 42 (NTP servers) with value "10.0.0.1".
+type DHCPOption struct {
+	Code  int    `xml:"code,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// reservedDHCPOptions are set by DHCPPool fields rather than DHCPOption.
+var reservedDHCPOptions = map[int]string{
+	1:  "Netmask",
+	3:  "Gateway",
+	6:  "Dns1 and Dns2",
+	51: "LeaseTime",
+}
+
+func (p DHCPPool) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if p.Vlan < 1 || p.Vlan > 4094 {
+		return fmt.Errorf("invalid VLAN %d", p.Vlan)
+	}
+	if p.LeaseTime <= 0 {
+		return errors.New("lease time must be positive")
+	}
+
+	subnet, netmask := p.Subnet.To4(), p.Netmask.To4()
+	if subnet == nil || netmask == nil {
+		return errors.New("subnet and netmask must be IPv4 addresses")
+	}
+	if ones, bits := net.IPMask(netmask).Size(); bits == 0 || ones == 0 {
+		return fmt.Errorf("invalid netmask %s", p.Netmask)
+	}
+	network := &net.IPNet{IP: subnet.Mask(net.IPMask(netmask)), Mask: net.IPMask(netmask)}
+	if !network.IP.Equal(subnet) {
+		return fmt.Errorf("subnet %s has host bits set for netmask %s", p.Subnet, p.Netmask)
+	}
+
+	start, end := p.StartIp.To4(), p.EndIp.To4()
+	if start == nil || end == nil {
+		return errors.New("start and end addresses must be IPv4 addresses")
+	}
+	if !network.Contains(start) || !network.Contains(end) {
+		return fmt.Errorf("range %s-%s is outside %s", p.StartIp, p.EndIp, network)
+	}
+	if bytes.Compare(start, end) > 0 {
+		return fmt.Errorf("range %s-%s ends before it starts", p.StartIp, p.EndIp)
+	}
+
+	if p.Gateway != nil {
+		if gateway := p.Gateway.To4(); gateway == nil || !network.Contains(gateway) {
+			return fmt.Errorf("gateway %s is outside %s", p.Gateway, network)
+		} else if bytes.Compare(start, gateway) <= 0 && bytes.Compare(gateway, end) <= 0 {
+			return fmt.Errorf("gateway %s is inside range %s-%s", p.Gateway, p.StartIp, p.EndIp)
+		}
+	}
+	for _, dns := range []net.IP{p.Dns1, p.Dns2} {
+		if dns != nil && dns.To4() == nil {
+			return fmt.Errorf("DNS server %s is not an IPv4 address", dns)
+		}
+	}
+
+	for i, option := range p.Options {
+		if option.Code < 1 || option.Code > 254 {
+			return fmt.Errorf("option %d: invalid code %d", i+1, option.Code)
+		}
+		if field, ok := reservedDHCPOptions[option.Code]; ok {
+			return fmt.Errorf("option %d: code %d is set by %s", i+1, option.Code, field)
+		}
+	}
+	return nil
+}
+
+func (d DHCPPools) List(ctx context.Context) ([]DHCPPool, error) {
+	var resp struct {
+		XMLName xml.Name   `xml:"dhcpsvr-list"`
+		Pools   []DHCPPool `xml:"dhcpsvr"`
+	}
+
+	if err := d.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "dhcpsvr-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Pools, nil
+	}
+}
+
+// Create creates a DHCPPool, returning the created record.
+func (d DHCPPools) Create(ctx context.Context, pool DHCPPool) (*DHCPPool, error) {
+	var req struct {
+		XMLName xml.Name `xml:"dhcpsvr"`
+		DHCPPool
+	}
+	req.DHCPPool = pool
+	req.DHCPPool.ID = 0 // ensure we don't specify one
+
+	if err := req.DHCPPool.validate(); err != nil {
+		return nil, fmt.Errorf("invalid DHCPPool: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"dhcpsvr"`
+		DHCPPool
+	}
+
+	if err := d.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "dhcpsvr-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.DHCPPool, nil
+	}
+}
+
+// Update updates a DHCPPool, replacing the record.
+func (d DHCPPools) Update(ctx context.Context, pool DHCPPool) error {
+	req := struct {
+		XMLName xml.Name `xml:"dhcpsvr"`
+		DHCPPool
+	}{
+		DHCPPool: pool,
+	}
+
+	if err := req.DHCPPool.validate(); err != nil {
+		return fmt.Errorf("invalid DHCPPool: %v", err)
+	}
+
+	return d.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "dhcpsvr-list",
+	}, &req, nil)
+}
+
+// Delete a DHCPPool by ID. It must not be in use by any Wlan.
+func (d DHCPPools) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"dhcpsvr"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return d.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "dhcpsvr-list",
+	}, &req, nil)
+}
+
+// Bind serves addresses to stations on the named Wlan from the DHCPPool with the given ID.
+func (d DHCPPools) Bind(ctx context.Context, id int, wlanName string) error {
+	return d.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.DhcpsvrID == id {
+			return false
+		}
+		wlan.DhcpsvrID = id
+		return true
+	})
+}
+
+// Unbind stops serving addresses to the named Wlan from a specific DHCPPool.
+func (d DHCPPools) Unbind(ctx context.Context, wlanName string) error {
+	return d.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.DhcpsvrID == 0 {
+			return false
+		}
+		wlan.DhcpsvrID = 0
+		return true
+	})
+}
+
+// DHCPLease is an address currently leased by the built-in DHCP server.
+type DHCPLease struct {
+	Mac      MacAddress `xml:"mac,attr"`
+	Ip       net.IP     `xml:"ip,attr"`
+	Hostname string     `xml:"hostname,attr"`
+	// PoolID refers to the DHCPPool the address was leased from.
+	PoolID  int       `xml:"dhcpsvr-id,attr"`
+	Expires Timestamp `xml:"expire,attr"`
+}
+
+// Leases returns the current leases of every DHCPPool.
+func (d DHCPPools) Leases(ctx context.Context) ([]DHCPLease, error) {
+	var req struct {
+		XMLName xml.Name `xml:"ajax-request"`
+		Action  string   `xml:"action,attr"`
+		Updater string   `xml:"updater,attr"`
+		Comp    string   `xml:"comp,attr"`
+		Leases  struct{} `xml:"dhcp-lease"`
+	}
+	req.Action = "getstat"
+	req.Comp = "system"
+
+	var resp struct {
+		XMLName  xml.Name `xml:"ajax-response"`
+		Response struct {
+			Type      string `xml:"type,attr"`
+			ID        string `xml:"id,attr"`
+			DhcpLease struct {
+				Lease []DHCPLease `xml:"lease"`
+			} `xml:"dhcp-lease"`
+		} `xml:"response"`
+	}
+
+	if err := d.c.cmdstat(ctx, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Response.DhcpLease.Lease, nil
+	}
+}
@@ -0,0 +1,107 @@
+package ruckusweb
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDHCPPoolValidate(t *testing.T) {
+	base := func() DHCPPool {
+		return DHCPPool{
+			Name:      "lan",
+			Vlan:      10,
+			Subnet:    net.ParseIP("192.168.10.0"),
+			Netmask:   net.ParseIP("255.255.255.0"),
+			StartIp:   net.ParseIP("192.168.10.100"),
+			EndIp:     net.ParseIP("192.168.10.200"),
+			LeaseTime: 86400,
+			Gateway:   net.ParseIP("192.168.10.1"),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *DHCPPool)
+		want   string
+	}{
+		{
+			"valid",
+			func(p *DHCPPool) {},
+			"",
+		},
+		{
+			"no gateway",
+			func(p *DHCPPool) { p.Gateway = nil },
+			"",
+		},
+		{
+			"non-contiguous netmask",
+			func(p *DHCPPool) { p.Netmask = net.ParseIP("255.0.255.0") },
+			"invalid netmask 255.0.255.0",
+		},
+		{
+			"zero netmask",
+			func(p *DHCPPool) { p.Netmask = net.ParseIP("0.0.0.0") },
+			"invalid netmask 0.0.0.0",
+		},
+		{
+			"host bits in subnet",
+			func(p *DHCPPool) { p.Subnet = net.ParseIP("192.168.10.5") },
+			"subnet 192.168.10.5 has host bits set for netmask 255.255.255.0",
+		},
+		{
+			"range outside subnet",
+			func(p *DHCPPool) { p.EndIp = net.ParseIP("192.168.11.10") },
+			"range 192.168.10.100-192.168.11.10 is outside 192.168.10.0/24",
+		},
+		{
+			"range reversed",
+			func(p *DHCPPool) { p.StartIp, p.EndIp = p.EndIp, p.StartIp },
+			"range 192.168.10.200-192.168.10.100 ends before it starts",
+		},
+		{
+			"gateway inside range",
+			func(p *DHCPPool) { p.Gateway = net.ParseIP("192.168.10.150") },
+			"gateway 192.168.10.150 is inside range 192.168.10.100-192.168.10.200",
+		},
+		{
+			"gateway at end of range",
+			func(p *DHCPPool) { p.Gateway = net.ParseIP("192.168.10.200") },
+			"gateway 192.168.10.200 is inside range 192.168.10.100-192.168.10.200",
+		},
+		{
+			"gateway outside subnet",
+			func(p *DHCPPool) { p.Gateway = net.ParseIP("10.0.0.1") },
+			"gateway 10.0.0.1 is outside 192.168.10.0/24",
+		},
+		{
+			"custom option",
+			func(p *DHCPPool) { p.Options = []DHCPOption{{Code: 42, Value: "192.168.10.1"}} },
+			"",
+		},
+		{
+			"reserved option",
+			func(p *DHCPPool) { p.Options = []DHCPOption{{Code: 42}, {Code: 3, Value: "192.168.10.1"}} },
+			"option 2: code 3 is set by Gateway",
+		},
+		{
+			"invalid option code",
+			func(p *DHCPPool) { p.Options = []DHCPOption{{Code: 255}} },
+			"option 1: invalid code 255",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := base()
+			tt.modify(&p)
+			err := p.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// EventFilter selects a page of events or alarms. The zero value selects the DefaultEventLimit most recent records.
+type EventFilter struct {
+	// Since and Until bound the time range of returned records. Zero values are unbounded.
+	Since time.Time
+	Until time.Time
+
+	// Offset skips this many of the newest matching records.
+	Offset int
+	// Limit caps the number of returned records. Zero means DefaultEventLimit.
+	Limit int
+}
+
+// DefaultEventLimit is the number of records returned for an EventFilter with no Limit.
+const DefaultEventLimit = 100
+
+// maxEventPages bounds how many pages are requested for one EventFilter.
+const maxEventPages = 100
+
+func (f EventFilter) attrs(start, number int) []xml.Attr {
+	attrs := []xml.Attr{
+		{Name: xml.Name{Local: "sortBy"}, Value: "time"},
+		{Name: xml.Name{Local: "sortDirection"}, Value: "-1"},
+		{Name: xml.Name{Local: "start"}, Value: strconv.Itoa(start)},
+		{Name: xml.Name{Local: "number"}, Value: strconv.Itoa(number)},
+	}
+	if !f.Since.IsZero() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "INTERVAL-START"}, Value: strconv.FormatInt(f.Since.Unix(), 10)})
+	}
+	if !f.Until.IsZero() {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "INTERVAL-STOP"}, Value: strconv.FormatInt(f.Until.Unix(), 10)})
+	}
+	return attrs
+}
+
+// matches reports whether a record at t falls within the filter's time range.
+func (f EventFilter) matches(t time.Time) bool {
+	if !f.Since.IsZero() && t.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && t.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// pageRecords applies filter to records fetched newest first, one page at a time.
+//
+// Older firmware ignores the interval attributes and returns records from outside the time range, so a page may
+// contain fewer matches than requested. Offset and Limit therefore count matching records, and pages are fetched until
+// Limit matches are found, the records run out, or they become older than Since. Paging also stops if a page starts
+// with the same record as the one before, as happens when the device ignores the "start" attribute.
+func pageRecords[T any](filter EventFilter, fetch func(start, number int) ([]T, error), timeOf func(T) time.Time) ([]T, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultEventLimit
+	}
+
+	// Matches are usually contiguous, so ask for enough records to cover Offset and Limit in one page
+	pageSize := filter.Offset + limit
+
+	var out []T
+	var previousFirst *T
+	skipped, start := 0, 0
+	for page := 0; page < maxEventPages; page++ {
+		records, err := fetch(start, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			if previousFirst != nil && reflect.DeepEqual(*previousFirst, records[0]) {
+				break
+			}
+			previousFirst = &records[0]
+		}
+
+		for _, record := range records {
+			t := timeOf(record)
+			if !filter.matches(t) {
+				if !filter.Since.IsZero() && t.Before(filter.Since) {
+					// Everything after this is older still
+					return out, nil
+				}
+				continue
+			}
+			if skipped < filter.Offset {
+				skipped++
+				continue
+			}
+			out = append(out, record)
+			if len(out) == limit {
+				return out, nil
+			}
+		}
+
+		if len(records) < pageSize {
+			break
+		}
+		start += len(records)
+	}
+	return out, nil
+}
+
+// Event is an entry in the device's event log.
+type Event struct {
+	Time     Timestamp `xml:"time,attr"`
+	Severity string    `xml:"severity,attr"`
+	Category string    `xml:"c,attr"`
+	// MsgID identifies the kind of event, e.g. "MSG_AP_joined".
+	MsgID   string     `xml:"msg,attr"`
+	Message string     `xml:"lmsg,attr"`
+	Ap      MacAddress `xml:"ap,attr"`
+	Client  MacAddress `xml:"sta,attr"`
+}
+
+type Events struct {
+	c *Client
+}
+
+func (c *Client) Events() Events {
+	return Events{c}
+}
+
+// List returns events matching filter, newest first.
+func (e Events) List(ctx context.Context, filter EventFilter) ([]Event, error) {
+	var req struct {
+		XMLName xml.Name    `xml:"ajax-request"`
+		Action  string      `xml:"action,attr"`
+		Updater string      `xml:"updater,attr"`
+		Comp    string      `xml:"comp,attr"`
+		Xevent  attrElement `xml:"xevent"`
+	}
+	req.Action = "getstat"
+	req.Comp = "eventd"
+
+	fetch := func(start, number int) ([]Event, error) {
+		req.Xevent.Attrs = filter.attrs(start, number)
+
+		var resp struct {
+			XMLName  xml.Name `xml:"ajax-response"`
+			Response struct {
+				Type   string  `xml:"type,attr"`
+				ID     string  `xml:"id,attr"`
+				Xevent []Event `xml:"xevent"`
+			} `xml:"response"`
+		}
+
+		if err := e.c.cmdstat(ctx, &req, &resp); err != nil {
+			return nil, err
+		} else {
+			return resp.Response.Xevent, nil
+		}
+	}
+	return pageRecords(filter, fetch, func(event Event) time.Time { return event.Time.Time })
+}
+
+// Alarm is an entry in the device's alarm list. Alarms are events which remain until they are cleared.
+type Alarm struct {
+	ID       int       `xml:"id,attr"`
+	Time     Timestamp `xml:"time,attr"`
+	Severity string    `xml:"severity,attr"`
+	Category string    `xml:"c,attr"`
+	Name     string    `xml:"name,attr"`
+	// MsgID identifies the kind of alarm, e.g. "MSG_AP_lost".
+	MsgID   string     `xml:"msg,attr"`
+	Message string     `xml:"lmsg,attr"`
+	Ap      MacAddress `xml:"ap,attr"`
+	Client  MacAddress `xml:"sta,attr"`
+}
+
+type Alarms struct {
+	c *Client
+}
+
+func (c *Client) Alarms() Alarms {
+	return Alarms{c}
+}
+
+// List returns alarms matching filter, newest first.
+func (a Alarms) List(ctx context.Context, filter EventFilter) ([]Alarm, error) {
+	var req struct {
+		XMLName xml.Name    `xml:"ajax-request"`
+		Action  string      `xml:"action,attr"`
+		Updater string      `xml:"updater,attr"`
+		Comp    string      `xml:"comp,attr"`
+		Alarm   attrElement `xml:"alarm"`
+	}
+	req.Action = "getstat"
+	req.Comp = "eventd"
+
+	fetch := func(start, number int) ([]Alarm, error) {
+		req.Alarm.Attrs = filter.attrs(start, number)
+
+		var resp struct {
+			XMLName  xml.Name `xml:"ajax-response"`
+			Response struct {
+				Type  string  `xml:"type,attr"`
+				ID    string  `xml:"id,attr"`
+				Alarm []Alarm `xml:"alarm"`
+			} `xml:"response"`
+		}
+
+		if err := a.c.cmdstat(ctx, &req, &resp); err != nil {
+			return nil, err
+		} else {
+			return resp.Response.Alarm, nil
+		}
+	}
+	return pageRecords(filter, fetch, func(alarm Alarm) time.Time { return alarm.Time.Time })
+}
+
+// Clear clears the alarms with the given IDs, or every alarm if no IDs are given.
+func (a Alarms) Clear(ctx context.Context, ids ...int) error {
+	type xcmd struct {
+		Cmd string `xml:"cmd,attr"`
+		ID  int    `xml:"id,attr,omitempty"`
+	}
+
+	if len(ids) == 0 {
+		return a.c.docmd(ctx, "eventd", &xcmd{Cmd: "clear-all-alarms"})
+	}
+	for _, id := range ids {
+		if err := a.c.docmd(ctx, "eventd", &xcmd{Cmd: "clear-alarm", ID: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package ruckusweb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageRecords(t *testing.T) {
+	// 30 records, newest first, one per minute, from a device which ignores the time range
+	base := time.Unix(1_700_000_000, 0)
+	var all []time.Time
+	for i := 29; i >= 0; i-- {
+		all = append(all, base.Add(time.Duration(i)*time.Minute))
+	}
+	fetches := 0
+	fetch := func(start, number int) ([]time.Time, error) {
+		fetches++
+		if start >= len(all) {
+			return nil, nil
+		}
+		end := start + number
+		if end > len(all) {
+			end = len(all)
+		}
+		return all[start:end], nil
+	}
+	identity := func(t time.Time) time.Time { return t }
+	minute := func(i int) time.Time { return base.Add(time.Duration(i) * time.Minute) }
+
+	// ignoresStart returns the first page whatever start is requested
+	ignoresStart := func(start, number int) ([]time.Time, error) {
+		fetches++
+		if number > len(all) {
+			number = len(all)
+		}
+		return all[:number], nil
+	}
+
+	tests := []struct {
+		name    string
+		filter  EventFilter
+		fetch   func(start, number int) ([]time.Time, error)
+		want    []time.Time
+		fetches int
+	}{
+		{
+			name:    "first page",
+			filter:  EventFilter{Limit: 3},
+			want:    []time.Time{minute(29), minute(28), minute(27)},
+			fetches: 1,
+		},
+		{
+			name:    "offset",
+			filter:  EventFilter{Offset: 2, Limit: 2},
+			want:    []time.Time{minute(27), minute(26)},
+			fetches: 1,
+		},
+		{
+			name:    "until spans pages",
+			filter:  EventFilter{Until: minute(10), Limit: 3},
+			want:    []time.Time{minute(10), minute(9), minute(8)},
+			fetches: 8,
+		},
+		{
+			name:    "offset counts matches",
+			filter:  EventFilter{Until: minute(10), Offset: 1, Limit: 2},
+			want:    []time.Time{minute(9), minute(8)},
+			fetches: 8,
+		},
+		{
+			name:    "stops at since",
+			filter:  EventFilter{Since: minute(25), Limit: 4},
+			want:    []time.Time{minute(29), minute(28), minute(27), minute(26)},
+			fetches: 1,
+		},
+		{
+			name:    "short result",
+			filter:  EventFilter{Since: minute(28), Limit: 5},
+			want:    []time.Time{minute(29), minute(28)},
+			fetches: 1,
+		},
+		{
+			name:    "runs out",
+			filter:  EventFilter{Until: minute(1), Limit: 10},
+			want:    []time.Time{minute(1), minute(0)},
+			fetches: 4,
+		},
+		{
+			name:    "device ignores start",
+			filter:  EventFilter{Until: minute(27), Limit: 3},
+			fetch:   ignoresStart,
+			want:    []time.Time{minute(27)},
+			fetches: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches = 0
+			f := fetch
+			if tt.fetch != nil {
+				f = tt.fetch
+			}
+			got, err := pageRecords(tt.filter, f, identity)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.fetches, fetches)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package ruckusweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+)
+
+// UpgradeFromFile uploads a firmware image to the device and asks it to upgrade.
+//
+// The device applies the image to itself and to every AP it manages, rebooting along the way. Use WatchUpgrade to
+// follow the upgrade through to completion.
+func (s System) UpgradeFromFile(ctx context.Context, image io.Reader) error {
+	var form bytes.Buffer
+	var contentType string
+	{
+		w := multipart.NewWriter(&form)
+		contentType = w.FormDataContentType()
+
+		inner, _ := w.CreateFormFile("u", "firmware.img")
+		if _, err := io.Copy(inner, image); err != nil {
+			return err
+		}
+
+		_ = w.WriteField("request_type", "xhr")
+		_ = w.WriteField("action", "uploadras")
+		_ = w.WriteField("callback", "uploader_uploadras")
+		_ = w.Close()
+	}
+
+	// ensure we're logged in
+	if _, err := s.c.getCsrfToken(ctx); err != nil {
+		return err
+	}
+
+	// construct the request
+	req, err := s.c.newRequestWithContext(ctx, http.MethodPost, "/admin/_upload.jsp", bytes.NewReader(form.Bytes()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	// execute it
+	resp, err := s.c.c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("firmware upload returned status code %d", resp.StatusCode)
+	}
+
+	// Parse the response
+	var respData struct {
+		Msg        string `json:"msg"`
+		Cf         string `json:"cf"`
+		Uploadfile string `json:"uploadfile"`
+		Size       int    `json:"size"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&respData); err != nil {
+		return err
+	}
+	if respData.Uploadfile == "" {
+		return fmt.Errorf("firmware upload failed: %q", respData.Msg)
+	}
+
+	// Start the upgrade
+	type xcmd struct {
+		Cmd        string `xml:"cmd,attr"`
+		Uploadfile string `xml:"uploadfile,attr"`
+	}
+	return s.c.docmd(ctx, "system", &xcmd{
+		Cmd:        "upgrade",
+		Uploadfile: respData.Uploadfile,
+	})
+}
+
+// MaxFirmwareImageSize is the largest image UpgradeFromURL will download.
+const MaxFirmwareImageSize = 256 << 20
+
+// UpgradeFromURL downloads a firmware image from url using client and uploads it to the device as per
+// UpgradeFromFile.
+//
+// The image is fetched by this process, not by the device, so client determines the transport and timeout used. Images
+// larger than MaxFirmwareImageSize are refused.
+func (s System) UpgradeFromURL(ctx context.Context, client *http.Client, url string) error {
+	if client == nil {
+		return errors.New("UpgradeFromURL requires an http.Client")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("firmware download returned status code %d", resp.StatusCode)
+	}
+
+	// Buffer the image so a slow download can't stall the upload
+	image, err := io.ReadAll(io.LimitReader(resp.Body, MaxFirmwareImageSize+1))
+	if err != nil {
+		return err
+	}
+	if len(image) > MaxFirmwareImageSize {
+		return fmt.Errorf("firmware image exceeds %d bytes", MaxFirmwareImageSize)
+	}
+
+	return s.UpgradeFromFile(ctx, bytes.NewReader(image))
+}
+
+// UpgradeWindow is a period of time during which an upgrade may be started.
+type UpgradeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Wait blocks until the window opens. It returns immediately if the window is already open, and returns an error if
+// the window has already closed or if ctx is cancelled first.
+func (w UpgradeWindow) Wait(ctx context.Context) error {
+	now := time.Now()
+	if !w.End.IsZero() && !now.Before(w.End) {
+		return errors.New("upgrade window has already closed")
+	}
+	if !now.Before(w.Start) {
+		return nil
+	}
+
+	t := time.NewTimer(w.Start.Sub(now))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// APUpgradeState describes where a single AP is in an upgrade.
+type APUpgradeState struct {
+	Mac             MacAddress
+	Devname         string
+	State           string
+	FirmwareVersion string
+	// Upgraded is true once the AP reports the target firmware version.
+	Upgraded bool
+}
+
+// UpgradeProgress is a snapshot of an upgrade in progress.
+type UpgradeProgress struct {
+	At  time.Time
+	APs []APUpgradeState
+	// Err is set if the device could not be polled, which is expected while it reboots.
+	Err error
+}
+
+// Done returns true if every AP reports the target firmware version.
+func (p UpgradeProgress) Done() bool {
+	if p.Err != nil || len(p.APs) == 0 {
+		return false
+	}
+	for _, ap := range p.APs {
+		if !ap.Upgraded {
+			return false
+		}
+	}
+	return true
+}
+
+func newUpgradeProgress(at time.Time, statuses []APStatus, version string) UpgradeProgress {
+	p := UpgradeProgress{At: at}
+	for _, status := range statuses {
+		p.APs = append(p.APs, APUpgradeState{
+			Mac:             status.Mac,
+			Devname:         status.Devname,
+			State:           status.State,
+			FirmwareVersion: status.FirmwareVersion,
+			Upgraded:        status.FirmwareVersion == version,
+		})
+	}
+	return p
+}
+
+// DefaultUpgradePollInterval is used by WatchUpgrade when interval is not positive.
+const DefaultUpgradePollInterval = 10 * time.Second
+
+// WatchUpgrade polls the APs every interval and reports their progress towards firmware version, which should be in
+// the same form as APStatus.FirmwareVersion. An interval of 0 polls every DefaultUpgradePollInterval.
+//
+// The returned channel is closed once every AP reports version or when ctx is cancelled.
+func (s System) WatchUpgrade(ctx context.Context, version string, interval time.Duration) <-chan UpgradeProgress {
+	if interval <= 0 {
+		interval = DefaultUpgradePollInterval
+	}
+
+	ch := make(chan UpgradeProgress)
+	go func() {
+		defer close(ch)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			var p UpgradeProgress
+			statuses, err := s.c.APs().ListStatuses(ctx)
+			if err != nil {
+				// The device is probably rebooting, so start a new session next time
+				s.c.resetLogin()
+				p = UpgradeProgress{At: time.Now(), Err: err}
+			} else {
+				p = newUpgradeProgress(time.Now(), statuses, version)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- p:
+			}
+			if p.Done() {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return ch
+}
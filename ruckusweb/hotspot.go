@@ -0,0 +1,235 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MaxWalledGardenEntries is the maximum number of entries in a HotspotService's walled garden.
+const MaxWalledGardenEntries = 128
+
+// HotspotServices manages hotspot services, which send stations on a Wlan to an external WISPr captive portal and
+// authenticate them against a RADIUS server. Wlans refer to them by Wlan.HotspotID.
+type HotspotServices struct {
+	c *Client
+}
+
+func (c *Client) HotspotServices() HotspotServices {
+	return HotspotServices{c}
+}
+
+type HotspotService struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	// LoginURL is the external portal to which unauthenticated stations are redirected.
+	LoginURL string `xml:"login-page,attr"`
+	// StartURL is where stations are sent after logging in, or empty to continue to the page they first requested.
+	StartURL string `xml:"start-page,attr,omitempty"`
+
+	// SessionTimeout ends sessions after that many minutes, and IdleTimeout ends them after that many minutes
+	// without traffic. Zero disables either timeout.
+	SessionTimeout int `xml:"session-timeout,attr"`
+	IdleTimeout    int `xml:"idle-timeout,attr"`
+
+	// AuthServerID refers to the AaaRadius server which authenticates logins, and AcctServerID to the one which
+	// receives accounting, or 0 for none. AcctUpdateInterval is in minutes.
+	AuthServerID       int `xml:"auth-server-id,attr"`
+	AcctServerID       int `xml:"acct-server-id,attr"`
+	AcctUpdateInterval int `xml:"acct-upd-interval,attr"`
+
+	// MacBypass authenticates stations by MAC address against the AuthServerID first, skipping the portal for those
+	// the server accepts.
+	MacBypass bool `xml:"mac-bypass,attr"`
+
+	// LocationID and LocationName are sent to the portal and RADIUS server as the WISPr location attributes.
+	LocationID   string `xml:"location-id,attr"`
+	LocationName string `xml:"location-name,attr"`
+
+	// WalledGarden lists destinations reachable before logging in, like the portal's own assets or a payment
+	// provider.
+	WalledGarden []WalledGardenEntry `xml:"walled-garden"`
+}
+
+// WalledGardenEntry is a destination reachable without logging in: a domain name, which may start with "*." to
+// include its subdomains, or an IP address or CIDR network, each optionally followed by a numeric ":port".
+type WalledGardenEntry struct {
+	Destination string `xml:"destination,attr"`
+}
+
+func (e WalledGardenEntry) validate() error {
+	dest := e.Destination
+	if dest == "" {
+		return errors.New("destination must not be empty")
+	}
+	if strings.Contains(dest, "://") || strings.ContainsAny(dest, " \t") {
+		return fmt.Errorf("invalid destination %q: must be a domain name, IP address or network, not a URL", dest)
+	}
+
+	host := dest
+	if h, port, err := net.SplitHostPort(dest); err == nil {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("invalid destination %q: invalid port", dest)
+		}
+		host = h
+	}
+	if strings.Contains(host, "/") {
+		if _, _, err := net.ParseCIDR(host); err != nil {
+			return fmt.Errorf("invalid destination %q: invalid network", dest)
+		}
+		return nil
+	}
+	if net.ParseIP(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")) != nil {
+		return nil
+	}
+	if err := validDomainName(strings.TrimPrefix(host, "*.")); err != nil {
+		return fmt.Errorf("invalid destination %q: must be a domain name, IP address or network", dest)
+	}
+	return nil
+}
+
+func validHotspotURL(name, value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s must be an http or https URL, not %q", name, value)
+	}
+	return nil
+}
+
+func (h HotspotService) validate() error {
+	if len(h.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if err := validHotspotURL("login URL", h.LoginURL); err != nil {
+		return err
+	}
+	if h.StartURL != "" {
+		if err := validHotspotURL("start URL", h.StartURL); err != nil {
+			return err
+		}
+	}
+	if h.SessionTimeout < 0 || h.IdleTimeout < 0 || h.AcctUpdateInterval < 0 {
+		return errors.New("timeouts and intervals must not be negative")
+	}
+	if h.AuthServerID == 0 {
+		return errors.New("an authentication server must be set")
+	}
+	if len(h.WalledGarden) > MaxWalledGardenEntries {
+		return fmt.Errorf("too many walled garden entries: %d > %d", len(h.WalledGarden), MaxWalledGardenEntries)
+	}
+	for i, entry := range h.WalledGarden {
+		if err := entry.validate(); err != nil {
+			return fmt.Errorf("walled garden entry %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (h HotspotServices) List(ctx context.Context) ([]HotspotService, error) {
+	var resp struct {
+		XMLName  xml.Name         `xml:"hotspot-list"`
+		Services []HotspotService `xml:"hotspot"`
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "hotspot-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Services, nil
+	}
+}
+
+// Create creates a HotspotService, returning the created record.
+func (h HotspotServices) Create(ctx context.Context, service HotspotService) (*HotspotService, error) {
+	var req struct {
+		XMLName xml.Name `xml:"hotspot"`
+		HotspotService
+	}
+	req.HotspotService = service
+	req.HotspotService.ID = 0 // ensure we don't specify one
+
+	if err := req.HotspotService.validate(); err != nil {
+		return nil, fmt.Errorf("invalid HotspotService: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"hotspot"`
+		HotspotService
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "hotspot-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.HotspotService, nil
+	}
+}
+
+// Update updates a HotspotService, replacing the record.
+func (h HotspotServices) Update(ctx context.Context, service HotspotService) error {
+	req := struct {
+		XMLName xml.Name `xml:"hotspot"`
+		HotspotService
+	}{
+		HotspotService: service,
+	}
+
+	if err := req.HotspotService.validate(); err != nil {
+		return fmt.Errorf("invalid HotspotService: %v", err)
+	}
+
+	return h.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "hotspot-list",
+	}, &req, nil)
+}
+
+// Delete a HotspotService by ID. It must not be in use by any Wlan.
+func (h HotspotServices) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"hotspot"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return h.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "hotspot-list",
+	}, &req, nil)
+}
+
+// Bind turns the named Wlan into a hotspot Wlan using the HotspotService with the given ID.
+func (h HotspotServices) Bind(ctx context.Context, id int, wlanName string) error {
+	return h.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.Usage == WlanUsageHotspot && wlan.HotspotID == id {
+			return false
+		}
+		wlan.Usage = WlanUsageHotspot
+		wlan.HotspotID = id
+		return true
+	})
+}
+
+// Unbind returns the named Wlan to standard usage, letting stations connect without the portal.
+func (h HotspotServices) Unbind(ctx context.Context, wlanName string) error {
+	return h.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.Usage != WlanUsageHotspot && wlan.HotspotID == 0 {
+			return false
+		}
+		wlan.Usage = WlanUsageStandard
+		wlan.HotspotID = 0
+		return true
+	})
+}
@@ -0,0 +1,404 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Hotspot20Operators manages Hotspot 2.0 (Passpoint) operator profiles, which describe the venue and the identity
+// providers whose subscribers may connect. Wlans refer to them by Wlan.Hotspot20OperatorID.
+type Hotspot20Operators struct {
+	c *Client
+}
+
+func (c *Client) Hotspot20Operators() Hotspot20Operators {
+	return Hotspot20Operators{c}
+}
+
+// Hotspot20Providers manages Hotspot 2.0 identity provider profiles, which advertise the credentials a station may
+// authenticate with. Hotspot20Operators refer to them by Hotspot20Operator.Providers.
+type Hotspot20Providers struct {
+	c *Client
+}
+
+func (c *Client) Hotspot20Providers() Hotspot20Providers {
+	return Hotspot20Providers{c}
+}
+
+// ConnectionStatus is the state of a protocol and port advertised as a Hotspot20ConnectionCapability.
+type ConnectionStatus int
+
+const (
+	ConnectionStatusClosed ConnectionStatus = iota
+	ConnectionStatusOpen
+	ConnectionStatusUnknown
+)
+
+// EapMethod is an EAP method by its IANA-assigned type number.
+type EapMethod int
+
+const (
+	EapMethodTLS      EapMethod = 13
+	EapMethodSIM      EapMethod = 18
+	EapMethodTTLS     EapMethod = 21
+	EapMethodAKA      EapMethod = 23
+	EapMethodPEAP     EapMethod = 25
+	EapMethodAKAPrime EapMethod = 50
+)
+
+func (m EapMethod) valid() bool {
+	switch m {
+	case EapMethodTLS, EapMethodSIM, EapMethodTTLS, EapMethodAKA, EapMethodPEAP, EapMethodAKAPrime:
+		return true
+	default:
+		return false
+	}
+}
+
+type Hotspot20Operator struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	// FriendlyNames are the operator's names as shown to users, in one or more languages.
+	FriendlyNames []Hotspot20Name `xml:"friendly-name"`
+	// DomainNames are the operator's domains. Stations treat providers with a matching home domain as their home
+	// network rather than as roaming.
+	DomainNames []string `xml:"domain-name"`
+
+	// VenueGroup and VenueType classify the venue as defined by IEEE 802.11u, e.g. group 2 (business) type 8 (office
+	// building). VenueNames name the venue in one or more languages.
+	VenueGroup int             `xml:"venue-group,attr"`
+	VenueType  int             `xml:"venue-type,attr"`
+	VenueNames []Hotspot20Name `xml:"venue-name"`
+
+	// InternetAvailable advertises that the network provides Internet access.
+	InternetAvailable bool `xml:"internet-option,attr"`
+	// ConnectionCapabilities advertise which protocols and ports are usable through the network.
+	ConnectionCapabilities []Hotspot20ConnectionCapability `xml:"conn-cap"`
+
+	// Providers are the Hotspot20Providers whose subscribers may connect.
+	Providers []Hotspot20ProviderRef `xml:"hs20sp"`
+}
+
+// Hotspot20Name is a name in the language given by its ISO 639 code, e.g. "eng".
+type Hotspot20Name struct {
+	Lang string `xml:"lang,attr"`
+	Name string `xml:"name,attr"`
+}
+
+// Hotspot20ConnectionCapability advertises whether traffic with an IP protocol number and port is usable.
+type Hotspot20ConnectionCapability struct {
+	Protocol int              `xml:"protocol,attr"`
+	Port     int              `xml:"port,attr"`
+	Status   ConnectionStatus `xml:"status,attr"`
+}
+
+// Hotspot20ProviderRef refers to a Hotspot20Provider by ID.
+type Hotspot20ProviderRef struct {
+	ID int `xml:"id,attr"`
+}
+
+type Hotspot20Provider struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	// NAIRealms are the realms of the users' network access identifiers, e.g. "example.com" for
+	// "alice@example.com", along with the EAP methods used to authenticate them.
+	NAIRealms []Hotspot20NAIRealm `xml:"nai-realm"`
+	// RoamingConsortiumOIs identify roaming consortiums or providers by IEEE-assigned organization identifier, as 6 or
+	// 10 hexadecimal digits.
+	RoamingConsortiumOIs []string `xml:"roaming-consortium"`
+	// CellularNetworks identify mobile networks whose subscribers authenticate with their SIM credentials.
+	CellularNetworks []Hotspot20PLMN `xml:"plmn"`
+}
+
+type Hotspot20NAIRealm struct {
+	Name       string      `xml:"name,attr"`
+	EapMethods []EapMethod `xml:"eap-method"`
+}
+
+// Hotspot20PLMN is a public land mobile network, identified by its mobile country code and mobile network code.
+type Hotspot20PLMN struct {
+	Mcc string `xml:"mcc,attr"`
+	Mnc string `xml:"mnc,attr"`
+}
+
+// MaxHotspot20EapMethods is the maximum number of EAP methods in a Hotspot20NAIRealm.
+const MaxHotspot20EapMethods = 4
+
+func (o Hotspot20Operator) validate() error {
+	if len(o.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if len(o.FriendlyNames) == 0 {
+		return errors.New("at least one friendly name must be set")
+	}
+	for _, names := range [][]Hotspot20Name{o.FriendlyNames, o.VenueNames} {
+		for _, name := range names {
+			if len(name.Lang) < 2 || len(name.Lang) > 3 {
+				return fmt.Errorf("name %q: language must be a two- or three-letter code", name.Name)
+			}
+			if len(name.Name) == 0 || len(name.Name) > 252 {
+				return fmt.Errorf("name %q: must be between 1 and 252 characters", name.Name)
+			}
+		}
+	}
+	for _, domain := range o.DomainNames {
+		if err := validDomainName(domain); err != nil {
+			return err
+		}
+	}
+	if o.VenueGroup < 0 || o.VenueGroup > 11 {
+		return fmt.Errorf("invalid venue group %d", o.VenueGroup)
+	}
+	if o.VenueType < 0 || o.VenueType > 255 {
+		return fmt.Errorf("invalid venue type %d", o.VenueType)
+	}
+	for i, capability := range o.ConnectionCapabilities {
+		if capability.Protocol < 0 || capability.Protocol > 255 {
+			return fmt.Errorf("connection capability %d: invalid protocol %d", i+1, capability.Protocol)
+		}
+		if capability.Port < 0 || capability.Port > 65535 {
+			return fmt.Errorf("connection capability %d: invalid port %d", i+1, capability.Port)
+		}
+		if capability.Status < ConnectionStatusClosed || capability.Status > ConnectionStatusUnknown {
+			return fmt.Errorf("connection capability %d: invalid status %d", i+1, capability.Status)
+		}
+	}
+	if len(o.Providers) == 0 {
+		return errors.New("at least one provider must be set")
+	}
+	return nil
+}
+
+func (p Hotspot20Provider) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if len(p.NAIRealms) == 0 && len(p.RoamingConsortiumOIs) == 0 && len(p.CellularNetworks) == 0 {
+		return errors.New("at least one NAI realm, roaming consortium OI or cellular network must be set")
+	}
+	for _, realm := range p.NAIRealms {
+		if err := validDomainName(realm.Name); err != nil {
+			return fmt.Errorf("NAI realm: %v", err)
+		}
+		if len(realm.EapMethods) > MaxHotspot20EapMethods {
+			return fmt.Errorf("NAI realm %q: too many EAP methods: %d > %d", realm.Name, len(realm.EapMethods), MaxHotspot20EapMethods)
+		}
+		for _, method := range realm.EapMethods {
+			if !method.valid() {
+				return fmt.Errorf("NAI realm %q: unsupported EAP method %d", realm.Name, method)
+			}
+		}
+	}
+	for _, oi := range p.RoamingConsortiumOIs {
+		if _, err := hex.DecodeString(oi); err != nil || (len(oi) != 6 && len(oi) != 10) {
+			return fmt.Errorf("invalid roaming consortium OI %q: must be 6 or 10 hexadecimal digits", oi)
+		}
+	}
+	for _, plmn := range p.CellularNetworks {
+		if !allDigits(plmn.Mcc) || len(plmn.Mcc) != 3 {
+			return fmt.Errorf("invalid MCC %q: must be 3 digits", plmn.Mcc)
+		}
+		if !allDigits(plmn.Mnc) || len(plmn.Mnc) < 2 || len(plmn.Mnc) > 3 {
+			return fmt.Errorf("invalid MNC %q: must be 2 or 3 digits", plmn.Mnc)
+		}
+	}
+	return nil
+}
+
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (h Hotspot20Operators) List(ctx context.Context) ([]Hotspot20Operator, error) {
+	var resp struct {
+		XMLName   xml.Name            `xml:"hs20op-list"`
+		Operators []Hotspot20Operator `xml:"hs20op"`
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "hs20op-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Operators, nil
+	}
+}
+
+// Create creates a Hotspot20Operator, returning the created record.
+func (h Hotspot20Operators) Create(ctx context.Context, operator Hotspot20Operator) (*Hotspot20Operator, error) {
+	var req struct {
+		XMLName xml.Name `xml:"hs20op"`
+		Hotspot20Operator
+	}
+	req.Hotspot20Operator = operator
+	req.Hotspot20Operator.ID = 0 // ensure we don't specify one
+
+	if err := req.Hotspot20Operator.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Hotspot20Operator: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"hs20op"`
+		Hotspot20Operator
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "hs20op-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Hotspot20Operator, nil
+	}
+}
+
+// Update updates a Hotspot20Operator, replacing the record.
+func (h Hotspot20Operators) Update(ctx context.Context, operator Hotspot20Operator) error {
+	req := struct {
+		XMLName xml.Name `xml:"hs20op"`
+		Hotspot20Operator
+	}{
+		Hotspot20Operator: operator,
+	}
+
+	if err := req.Hotspot20Operator.validate(); err != nil {
+		return fmt.Errorf("invalid Hotspot20Operator: %v", err)
+	}
+
+	return h.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "hs20op-list",
+	}, &req, nil)
+}
+
+// Delete a Hotspot20Operator by ID. It must not be in use by any Wlan.
+func (h Hotspot20Operators) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"hs20op"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return h.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "hs20op-list",
+	}, &req, nil)
+}
+
+// Bind makes the named Wlan a Passpoint Wlan advertising the Hotspot20Operator with the given ID. The Wlan must use
+// 802.1X authentication. As Passpoint requires, Bind also enables proxy ARP (Wlan.Parp) and disables downstream group
+// addressed forwarding (Wlan.DisDgaf); Unbind leaves them as they are.
+func (h Hotspot20Operators) Bind(ctx context.Context, id int, wlanName string) error {
+	return h.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.Hotspot20OperatorID == id && wlan.Parp == 1 && wlan.DisDgaf == 1 {
+			return false
+		}
+		wlan.Hotspot20OperatorID = id
+		wlan.Parp = 1
+		wlan.DisDgaf = 1
+		return true
+	})
+}
+
+// Unbind disables Hotspot 2.0 on the named Wlan.
+func (h Hotspot20Operators) Unbind(ctx context.Context, wlanName string) error {
+	return h.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.Hotspot20OperatorID == 0 {
+			return false
+		}
+		wlan.Hotspot20OperatorID = 0
+		return true
+	})
+}
+
+func (h Hotspot20Providers) List(ctx context.Context) ([]Hotspot20Provider, error) {
+	var resp struct {
+		XMLName   xml.Name            `xml:"hs20sp-list"`
+		Providers []Hotspot20Provider `xml:"hs20sp"`
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "hs20sp-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Providers, nil
+	}
+}
+
+// Create creates a Hotspot20Provider, returning the created record.
+func (h Hotspot20Providers) Create(ctx context.Context, provider Hotspot20Provider) (*Hotspot20Provider, error) {
+	var req struct {
+		XMLName xml.Name `xml:"hs20sp"`
+		Hotspot20Provider
+	}
+	req.Hotspot20Provider = provider
+	req.Hotspot20Provider.ID = 0 // ensure we don't specify one
+
+	if err := req.Hotspot20Provider.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Hotspot20Provider: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"hs20sp"`
+		Hotspot20Provider
+	}
+
+	if err := h.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "hs20sp-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Hotspot20Provider, nil
+	}
+}
+
+// Update updates a Hotspot20Provider, replacing the record.
+func (h Hotspot20Providers) Update(ctx context.Context, provider Hotspot20Provider) error {
+	req := struct {
+		XMLName xml.Name `xml:"hs20sp"`
+		Hotspot20Provider
+	}{
+		Hotspot20Provider: provider,
+	}
+
+	if err := req.Hotspot20Provider.validate(); err != nil {
+		return fmt.Errorf("invalid Hotspot20Provider: %v", err)
+	}
+
+	return h.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "hs20sp-list",
+	}, &req, nil)
+}
+
+// Delete a Hotspot20Provider by ID. It must not be in use by any Hotspot20Operator.
+func (h Hotspot20Providers) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"hs20sp"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return h.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "hs20sp-list",
+	}, &req, nil)
+}
@@ -0,0 +1,108 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotspot20OperatorValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Hotspot20Operator)
+		want string
+	}{
+		{"valid", func(o *Hotspot20Operator) {}, ""},
+		{"no friendly name", func(o *Hotspot20Operator) { o.FriendlyNames = nil }, "at least one friendly name must be set"},
+		{"bad language", func(o *Hotspot20Operator) {
+			o.VenueNames = []Hotspot20Name{{Lang: "english", Name: "Office"}}
+		}, `name "Office": language must be a two- or three-letter code`},
+		{"domain URL", func(o *Hotspot20Operator) {
+			o.DomainNames = []string{"https://example.com"}
+		}, `invalid domain "https://example.com": must be a bare domain name like "example.com"`},
+		{"venue group", func(o *Hotspot20Operator) { o.VenueGroup = 12 }, "invalid venue group 12"},
+		{"capability port", func(o *Hotspot20Operator) {
+			o.ConnectionCapabilities = []Hotspot20ConnectionCapability{{Protocol: 6, Port: 70000}}
+		}, "connection capability 1: invalid port 70000"},
+		{"capability status", func(o *Hotspot20Operator) {
+			o.ConnectionCapabilities = []Hotspot20ConnectionCapability{{Protocol: 6, Port: 443, Status: ConnectionStatus(3)}}
+		}, "connection capability 1: invalid status 3"},
+		{"no providers", func(o *Hotspot20Operator) { o.Providers = nil }, "at least one provider must be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operator := Hotspot20Operator{
+				Name:          "office",
+				FriendlyNames: []Hotspot20Name{{Lang: "eng", Name: "Example Office"}},
+				DomainNames:   []string{"example.com"},
+				VenueGroup:    2,
+				VenueType:     8,
+				ConnectionCapabilities: []Hotspot20ConnectionCapability{
+					{Protocol: 6, Port: 443, Status: ConnectionStatusOpen},
+				},
+				Providers: []Hotspot20ProviderRef{{ID: 1}},
+			}
+			tt.edit(&operator)
+			err := operator.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHotspot20ProviderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Hotspot20Provider)
+		want string
+	}{
+		{"valid", func(p *Hotspot20Provider) {}, ""},
+		{"roaming consortium only", func(p *Hotspot20Provider) {
+			p.NAIRealms, p.CellularNetworks = nil, nil
+		}, ""},
+		{"nothing advertised", func(p *Hotspot20Provider) {
+			p.NAIRealms, p.RoamingConsortiumOIs, p.CellularNetworks = nil, nil, nil
+		}, "at least one NAI realm, roaming consortium OI or cellular network must be set"},
+		{"realm with user", func(p *Hotspot20Provider) {
+			p.NAIRealms[0].Name = "example.com/alice"
+		}, `NAI realm: invalid domain "example.com/alice": must be a bare domain name like "example.com"`},
+		{"too many EAP methods", func(p *Hotspot20Provider) {
+			p.NAIRealms[0].EapMethods = []EapMethod{EapMethodTLS, EapMethodTTLS, EapMethodPEAP, EapMethodSIM, EapMethodAKA}
+		}, `NAI realm "example.com": too many EAP methods: 5 > 4`},
+		{"unsupported EAP method", func(p *Hotspot20Provider) {
+			p.NAIRealms[0].EapMethods = []EapMethod{4}
+		}, `NAI realm "example.com": unsupported EAP method 4`},
+		{"short OI", func(p *Hotspot20Provider) {
+			p.RoamingConsortiumOIs = []string{"5A03B"}
+		}, `invalid roaming consortium OI "5A03B": must be 6 or 10 hexadecimal digits`},
+		{"non-hex OI", func(p *Hotspot20Provider) {
+			p.RoamingConsortiumOIs = []string{"5A03BZ"}
+		}, `invalid roaming consortium OI "5A03BZ": must be 6 or 10 hexadecimal digits`},
+		{"MCC", func(p *Hotspot20Provider) {
+			p.CellularNetworks[0].Mcc = "31"
+		}, `invalid MCC "31": must be 3 digits`},
+		{"MNC", func(p *Hotspot20Provider) {
+			p.CellularNetworks[0].Mnc = "4a"
+		}, `invalid MNC "4a": must be 2 or 3 digits`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := Hotspot20Provider{
+				Name:                 "example",
+				NAIRealms:            []Hotspot20NAIRealm{{Name: "example.com", EapMethods: []EapMethod{EapMethodTTLS}}},
+				RoamingConsortiumOIs: []string{"5A03BA"},
+				CellularNetworks:     []Hotspot20PLMN{{Mcc: "310", Mnc: "410"}},
+			}
+			tt.edit(&provider)
+			err := provider.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotspotServiceValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*HotspotService)
+		want string
+	}{
+		{"valid", func(h *HotspotService) {}, ""},
+		{"start URL", func(h *HotspotService) { h.StartURL = "https://example.com/welcome" }, ""},
+		{"relative login URL", func(h *HotspotService) { h.LoginURL = "/login" }, `login URL must be an http or https URL, not "/login"`},
+		{"no auth server", func(h *HotspotService) { h.AuthServerID = 0 }, "an authentication server must be set"},
+		{"walled garden URL", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"https://pay.example.com"})
+		}, `walled garden entry 5: invalid destination "https://pay.example.com": must be a domain name, IP address or network, not a URL`},
+		{"walled garden network", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"10.0.0.0/33"})
+		}, `walled garden entry 5: invalid destination "10.0.0.0/33": invalid network`},
+		{"walled garden port", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"example.com:99999"})
+		}, `walled garden entry 5: invalid destination "example.com:99999": invalid port`},
+		{"walled garden port zero", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"example.com:0"})
+		}, `walled garden entry 5: invalid destination "example.com:0": invalid port`},
+		{"walled garden service name", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"example.com:https"})
+		}, `walled garden entry 5: invalid destination "example.com:https": invalid port`},
+		{"walled garden IPv6 port", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"[2001:db8::1]:443"})
+		}, ""},
+		{"walled garden IPv6", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"2001:db8::/32"}, WalledGardenEntry{"2001:db8::1"})
+		}, ""},
+		{"walled garden empty label", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"foo..bar"})
+		}, `walled garden entry 5: invalid destination "foo..bar": must be a domain name, IP address or network`},
+		{"walled garden wildcard only", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"*.*"})
+		}, `walled garden entry 5: invalid destination "*.*": must be a domain name, IP address or network`},
+		{"walled garden bad character", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"exa$mple"})
+		}, `walled garden entry 5: invalid destination "exa$mple": must be a domain name, IP address or network`},
+		{"walled garden hyphen", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"-"})
+		}, `walled garden entry 5: invalid destination "-": must be a domain name, IP address or network`},
+		{"walled garden inner wildcard", func(h *HotspotService) {
+			h.WalledGarden = append(h.WalledGarden, WalledGardenEntry{"pay.*.example.com"})
+		}, `walled garden entry 5: invalid destination "pay.*.example.com": must be a domain name, IP address or network`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := HotspotService{
+				Name:         "portal",
+				LoginURL:     "https://portal.example.com/login",
+				AuthServerID: 1,
+				WalledGarden: []WalledGardenEntry{
+					{"portal.example.com"},
+					{"*.cdn.example.com"},
+					{"192.0.2.0/24"},
+					{"198.51.100.7:8443"},
+				},
+			}
+			tt.edit(&service)
+			err := service.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWlanHotspotUsage(t *testing.T) {
+	wlan := NewWlan("guest")
+	wlan.Usage = WlanUsageHotspot
+	assert.EqualError(t, wlan.validate(), "hotspot WLANs require a HotspotID")
+
+	wlan.HotspotID = 3
+	assert.NoError(t, wlan.validate())
+
+	wlan.Usage = WlanUsageStandard
+	assert.EqualError(t, wlan.validate(), "HotspotID requires hotspot usage")
+}
@@ -0,0 +1,167 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// MaxIsolationWhitelistEntries is the maximum number of entries in an IsolationWhitelist.
+const MaxIsolationWhitelistEntries = 128
+
+// IsolationWhitelists manages client isolation whitelists, which list the devices that stations on a Wlan with client
+// isolation can still reach, like the gateway or a shared printer. Wlans refer to them by Wlan.CiWhitelistID.
+type IsolationWhitelists struct {
+	c *Client
+}
+
+func (c *Client) IsolationWhitelists() IsolationWhitelists {
+	return IsolationWhitelists{c}
+}
+
+type IsolationWhitelist struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	// AutoGateway whitelists each station's default gateway without listing it in Entries.
+	AutoGateway EnabledBool `xml:"enable-auto-gw,attr"`
+
+	Entries []IsolationWhitelistEntry `xml:"rule"`
+}
+
+// IsolationWhitelistEntry is a reachable device, identified by both its MAC and IP address.
+type IsolationWhitelistEntry struct {
+	Mac         MacAddress `xml:"mac,attr"`
+	Ip          net.IP     `xml:"ip,attr"`
+	Description string     `xml:"description,attr,omitempty"`
+}
+
+func (w IsolationWhitelist) validate() error {
+	if len(w.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if !w.AutoGateway && len(w.Entries) == 0 {
+		return errors.New("at least one entry must be set unless AutoGateway is enabled")
+	}
+	if len(w.Entries) > MaxIsolationWhitelistEntries {
+		return fmt.Errorf("too many entries: %d > %d", len(w.Entries), MaxIsolationWhitelistEntries)
+	}
+	for i, entry := range w.Entries {
+		if len(entry.Mac) == 0 {
+			return fmt.Errorf("entry %d: MAC address must be set", i+1)
+		}
+		if entry.Ip.To4() == nil {
+			return fmt.Errorf("entry %d: IPv4 address must be set", i+1)
+		}
+		for _, other := range w.Entries[:i] {
+			if macEqual(entry.Mac, other.Mac) {
+				return fmt.Errorf("entry %d: duplicate MAC address %s", i+1, net.HardwareAddr(entry.Mac))
+			}
+		}
+	}
+	return nil
+}
+
+func (i IsolationWhitelists) List(ctx context.Context) ([]IsolationWhitelist, error) {
+	var resp struct {
+		XMLName    xml.Name             `xml:"whitelist-list"`
+		Whitelists []IsolationWhitelist `xml:"whitelist"`
+	}
+
+	if err := i.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "whitelist-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Whitelists, nil
+	}
+}
+
+// Create creates an IsolationWhitelist, returning the created record.
+func (i IsolationWhitelists) Create(ctx context.Context, whitelist IsolationWhitelist) (*IsolationWhitelist, error) {
+	var req struct {
+		XMLName xml.Name `xml:"whitelist"`
+		IsolationWhitelist
+	}
+	req.IsolationWhitelist = whitelist
+	req.IsolationWhitelist.ID = 0 // ensure we don't specify one
+
+	if err := req.IsolationWhitelist.validate(); err != nil {
+		return nil, fmt.Errorf("invalid IsolationWhitelist: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"whitelist"`
+		IsolationWhitelist
+	}
+
+	if err := i.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "whitelist-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.IsolationWhitelist, nil
+	}
+}
+
+// Update updates an IsolationWhitelist, replacing the record.
+func (i IsolationWhitelists) Update(ctx context.Context, whitelist IsolationWhitelist) error {
+	req := struct {
+		XMLName xml.Name `xml:"whitelist"`
+		IsolationWhitelist
+	}{
+		IsolationWhitelist: whitelist,
+	}
+
+	if err := req.IsolationWhitelist.validate(); err != nil {
+		return fmt.Errorf("invalid IsolationWhitelist: %v", err)
+	}
+
+	return i.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "whitelist-list",
+	}, &req, nil)
+}
+
+// Delete an IsolationWhitelist by ID. It must not be in use by any Wlan.
+func (i IsolationWhitelists) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"whitelist"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return i.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "whitelist-list",
+	}, &req, nil)
+}
+
+// Bind enables client isolation on the named Wlan, with the IsolationWhitelist with the given ID as its exceptions.
+func (i IsolationWhitelists) Bind(ctx context.Context, id int, wlanName string) error {
+	return i.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.ClientIsolation && wlan.CiWhitelistID == id {
+			return false
+		}
+		wlan.ClientIsolation = true
+		wlan.CiWhitelistID = id
+		return true
+	})
+}
+
+// Unbind removes the whitelist from the named Wlan. It leaves Wlan.ClientIsolation unchanged, so a Wlan bound with
+// Bind keeps isolating its stations, now without exceptions; disabling isolation takes a Wlans.Update.
+func (i IsolationWhitelists) Unbind(ctx context.Context, wlanName string) error {
+	return i.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.CiWhitelistID == 0 {
+			return false
+		}
+		wlan.CiWhitelistID = 0
+		return true
+	})
+}
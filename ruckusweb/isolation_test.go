@@ -0,0 +1,117 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsolationWhitelistValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(w *IsolationWhitelist)
+		want string
+	}{
+		{"valid", func(w *IsolationWhitelist) {}, ""},
+		{"no name", func(w *IsolationWhitelist) { w.Name = "" }, "name must be set"},
+		{"no entries", func(w *IsolationWhitelist) { w.Entries = nil }, "at least one entry must be set unless AutoGateway is enabled"},
+		{"auto gateway only", func(w *IsolationWhitelist) {
+			w.Entries = nil
+			w.AutoGateway = true
+		}, ""},
+		{"too many entries", func(w *IsolationWhitelist) {
+			w.Entries = make([]IsolationWhitelistEntry, MaxIsolationWhitelistEntries+1)
+		}, "too many entries: 129 > 128"},
+		{"no MAC", func(w *IsolationWhitelist) { w.Entries[1].Mac = nil }, "entry 2: MAC address must be set"},
+		{"no IP", func(w *IsolationWhitelist) { w.Entries[0].Ip = nil }, "entry 1: IPv4 address must be set"},
+		{"IPv6", func(w *IsolationWhitelist) { w.Entries[0].Ip = net.ParseIP("2001:db8::1") }, "entry 1: IPv4 address must be set"},
+		{"duplicate MAC", func(w *IsolationWhitelist) {
+			w.Entries[1].Mac = mustMac("00:00:00:00:00:01")
+		}, "entry 2: duplicate MAC address 00:00:00:00:00:01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			whitelist := IsolationWhitelist{
+				Name: "printers",
+				Entries: []IsolationWhitelistEntry{
+					{Mac: mustMac("00:00:00:00:00:01"), Ip: net.ParseIP("192.0.2.1")},
+					{Mac: mustMac("00:00:00:00:00:02"), Ip: net.ParseIP("192.0.2.2")},
+				},
+			}
+			tt.edit(&whitelist)
+			err := whitelist.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsolationWhitelistBinding(t *testing.T) {
+	listResponse := func(wlan Wlan) string {
+		data, err := xml.Marshal(struct {
+			XMLName xml.Name `xml:"wlansvc"`
+			Wlan
+		}{Wlan: wlan})
+		require.NoError(t, err)
+		return `<ajax-response><response type="object" id="wlansvc-list"><wlansvc-list>` + string(data) + `</wlansvc-list></response></ajax-response>`
+	}
+	const updated = `<ajax-response><response type="object" id="wlansvc-list"></response></ajax-response>`
+
+	unbound := NewWlan("guest")
+	bound := NewWlan("guest")
+	bound.ClientIsolation = true
+	bound.CiWhitelistID = 3
+
+	tests := []struct {
+		name   string
+		wlan   Wlan
+		action func(ctx context.Context, i IsolationWhitelists) error
+		want   []string // attributes of the update, or nil if none is sent
+	}{
+		{
+			name:   "bind enables isolation",
+			wlan:   unbound,
+			action: func(ctx context.Context, i IsolationWhitelists) error { return i.Bind(ctx, 3, "guest") },
+			want:   []string{`client-isolation="enabled"`, `ci-whitelist-id="3"`},
+		},
+		{
+			name:   "bind already bound",
+			wlan:   bound,
+			action: func(ctx context.Context, i IsolationWhitelists) error { return i.Bind(ctx, 3, "guest") },
+		},
+		{
+			name:   "unbind keeps isolation",
+			wlan:   bound,
+			action: func(ctx context.Context, i IsolationWhitelists) error { return i.Unbind(ctx, "guest") },
+			want:   []string{`client-isolation="enabled"`, `ci-whitelist-id="0"`},
+		},
+		{
+			name:   "unbind not bound",
+			wlan:   unbound,
+			action: func(ctx context.Context, i IsolationWhitelists) error { return i.Unbind(ctx, "guest") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device := &fakeDevice{responses: []string{listResponse(tt.wlan), updated}}
+			require.NoError(t, tt.action(context.Background(), newFakeClient(device).IsolationWhitelists()))
+
+			if tt.want == nil {
+				assert.Len(t, device.requests, 1)
+				return
+			}
+			require.Len(t, device.requests, 2)
+			assert.Contains(t, device.requests[1], `action="updobj"`)
+			for _, attr := range tt.want {
+				assert.Contains(t, device.requests[1], attr)
+			}
+		})
+	}
+}
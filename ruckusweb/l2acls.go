@@ -0,0 +1,252 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MaxL2ACLEntries is the maximum number of MAC addresses in an L2ACL.
+const MaxL2ACLEntries = 128
+
+type L2ACLs struct {
+	c *Client
+}
+
+func (c *Client) L2ACLs() L2ACLs {
+	return L2ACLs{c}
+}
+
+// L2ACL controls which stations may associate with a Wlan by MAC address. Wlans refer to it by Wlan.AclID.
+type L2ACL struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	// DefaultMode is "allow" to admit every station except those in Deny, or "deny" to admit only the stations in
+	// Accept.
+	DefaultMode string `xml:"default-mode,attr"`
+	// Editable is false for the built-in ACL.
+	Editable bool `xml:"EDITABLE,attr,omitempty"`
+
+	Accept []L2ACLEntry `xml:"accept"`
+	Deny   []L2ACLEntry `xml:"deny"`
+}
+
+type L2ACLEntry struct {
+	Mac MacAddress `xml:"mac,attr"`
+}
+
+func (a L2ACL) validate() error {
+	if len(a.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if a.DefaultMode != "allow" && a.DefaultMode != "deny" {
+		return fmt.Errorf("default mode must be \"allow\" or \"deny\", not %q", a.DefaultMode)
+	}
+	if len(a.Accept)+len(a.Deny) > MaxL2ACLEntries {
+		return fmt.Errorf("too many entries: %d > %d", len(a.Accept)+len(a.Deny), MaxL2ACLEntries)
+	}
+	return nil
+}
+
+// list returns the entries of the list which applies to stations given the ACL's DefaultMode.
+func (a *L2ACL) list() *[]L2ACLEntry {
+	if a.DefaultMode == "deny" {
+		return &a.Accept
+	}
+	return &a.Deny
+}
+
+func (l L2ACLs) List(ctx context.Context) ([]L2ACL, error) {
+	var resp struct {
+		XMLName xml.Name `xml:"acl-list"`
+		Acl     []L2ACL  `xml:"acl"`
+	}
+
+	if err := l.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "acl-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Acl, nil
+	}
+}
+
+// Get returns the L2ACL with the given ID.
+func (l L2ACLs) Get(ctx context.Context, id int) (*L2ACL, error) {
+	acls, err := l.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range acls {
+		if acls[i].ID == id {
+			return &acls[i], nil
+		}
+	}
+	return nil, fmt.Errorf("L2 ACL %d not found", id)
+}
+
+// Create creates an L2ACL, returning the created record.
+func (l L2ACLs) Create(ctx context.Context, acl L2ACL) (*L2ACL, error) {
+	var req struct {
+		XMLName xml.Name `xml:"acl"`
+		L2ACL
+	}
+	req.L2ACL = acl
+	req.L2ACL.ID = 0 // ensure we don't specify one
+	req.L2ACL.Editable = false
+
+	if err := req.L2ACL.validate(); err != nil {
+		return nil, fmt.Errorf("invalid L2ACL: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"acl"`
+		L2ACL
+	}
+
+	if err := l.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "acl-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.L2ACL, nil
+	}
+}
+
+// Update updates an L2ACL, replacing the record.
+func (l L2ACLs) Update(ctx context.Context, acl L2ACL) error {
+	req := struct {
+		XMLName xml.Name `xml:"acl"`
+		L2ACL
+	}{
+		L2ACL: acl,
+	}
+	req.L2ACL.Editable = false
+
+	if err := req.L2ACL.validate(); err != nil {
+		return fmt.Errorf("invalid L2ACL: %v", err)
+	}
+
+	return l.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "acl-list",
+	}, &req, nil)
+}
+
+// Delete an L2ACL by ID. It must not be in use by any Wlan.
+func (l L2ACLs) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"acl"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return l.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "acl-list",
+	}, &req, nil)
+}
+
+// AddMac adds mac to the list which applies given the ACL's DefaultMode: Accept for "deny" ACLs, or Deny for "allow"
+// ACLs. It does nothing if mac is already there.
+//
+// The device has no operation to add or remove a single entry, so AddMac and RemoveMac are not atomic. They read the
+// ACL, read it again just before writing it back, and start over if it changed in between; after writing, they read it
+// once more and start over if the edit is missing. Calls through the same Client are serialized, but a change made by
+// anyone else between the second read and the write is still lost.
+func (l L2ACLs) AddMac(ctx context.Context, id int, mac MacAddress) error {
+	return l.edit(ctx, id, func(acl *L2ACL) bool {
+		return acl.addMac(mac)
+	})
+}
+
+// RemoveMac removes mac from both of the ACL's lists. It does nothing if mac is not present. See AddMac regarding
+// concurrent changes.
+func (l L2ACLs) RemoveMac(ctx context.Context, id int, mac MacAddress) error {
+	return l.edit(ctx, id, func(acl *L2ACL) bool {
+		return acl.removeMac(mac)
+	})
+}
+
+func (a *L2ACL) addMac(mac MacAddress) bool {
+	list := a.list()
+	for _, entry := range *list {
+		if macEqual(entry.Mac, mac) {
+			return false
+		}
+	}
+	*list = append(*list, L2ACLEntry{Mac: mac})
+	return true
+}
+
+func (a *L2ACL) removeMac(mac MacAddress) bool {
+	remove := func(list []L2ACLEntry) ([]L2ACLEntry, bool) {
+		var out []L2ACLEntry
+		for _, entry := range list {
+			if !macEqual(entry.Mac, mac) {
+				out = append(out, entry)
+			}
+		}
+		return out, len(out) != len(list)
+	}
+
+	var removedAccept, removedDeny bool
+	a.Accept, removedAccept = remove(a.Accept)
+	a.Deny, removedDeny = remove(a.Deny)
+	return removedAccept || removedDeny
+}
+
+// maxL2ACLEditAttempts limits how many times edit starts over because the ACL changed underneath it.
+const maxL2ACLEditAttempts = 3
+
+// edit reads an ACL, applies fn, and writes the ACL back if fn reports a change and the ACL is unchanged on the device.
+func (l L2ACLs) edit(ctx context.Context, id int, fn func(acl *L2ACL) bool) error {
+	l.c.editM.Lock()
+	defer l.c.editM.Unlock()
+
+	return editL2ACL(
+		func() (*L2ACL, error) { return l.Get(ctx, id) },
+		func(acl L2ACL) error { return l.Update(ctx, acl) },
+		fn,
+	)
+}
+
+// editL2ACL implements edit. fn must report no change once its edit has been made, which is how a write is verified.
+func editL2ACL(get func() (*L2ACL, error), update func(L2ACL) error, fn func(acl *L2ACL) bool) error {
+	acl, err := get()
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		edited := *acl
+		edited.Accept = append([]L2ACLEntry(nil), acl.Accept...)
+		edited.Deny = append([]L2ACLEntry(nil), acl.Deny...)
+		if !fn(&edited) {
+			return nil
+		}
+		if attempt > maxL2ACLEditAttempts {
+			return fmt.Errorf("L2 ACL %d changed during %d attempts to edit it", acl.ID, maxL2ACLEditAttempts)
+		}
+
+		current, err := get()
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(acl, current) {
+			if err := update(edited); err != nil {
+				return err
+			}
+			// Read it back, since a write by anyone else after the second read replaced ours
+			if current, err = get(); err != nil {
+				return err
+			}
+		}
+		acl = current
+	}
+}
@@ -0,0 +1,165 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2ACLMac(t *testing.T) {
+	mac1 := mustMac("00:00:00:00:00:01")
+	mac2 := mustMac("00:00:00:00:00:02")
+
+	tests := []struct {
+		name        string
+		acl         L2ACL
+		edit        func(acl *L2ACL) bool
+		wantChanged bool
+		wantAccept  []MacAddress
+		wantDeny    []MacAddress
+	}{
+		{
+			"add to deny-mode ACL",
+			L2ACL{DefaultMode: "deny"},
+			func(acl *L2ACL) bool { return acl.addMac(mac1) },
+			true,
+			[]MacAddress{mac1},
+			nil,
+		},
+		{
+			"add to allow-mode ACL",
+			L2ACL{DefaultMode: "allow", Deny: []L2ACLEntry{{mac1}}},
+			func(acl *L2ACL) bool { return acl.addMac(mac2) },
+			true,
+			nil,
+			[]MacAddress{mac1, mac2},
+		},
+		{
+			"add existing",
+			L2ACL{DefaultMode: "deny", Accept: []L2ACLEntry{{mac1}}},
+			func(acl *L2ACL) bool { return acl.addMac(mac1) },
+			false,
+			[]MacAddress{mac1},
+			nil,
+		},
+		{
+			"remove from both lists",
+			L2ACL{DefaultMode: "allow", Accept: []L2ACLEntry{{mac1}}, Deny: []L2ACLEntry{{mac2}, {mac1}}},
+			func(acl *L2ACL) bool { return acl.removeMac(mac1) },
+			true,
+			nil,
+			[]MacAddress{mac2},
+		},
+		{
+			"remove missing",
+			L2ACL{DefaultMode: "allow", Deny: []L2ACLEntry{{mac2}}},
+			func(acl *L2ACL) bool { return acl.removeMac(mac1) },
+			false,
+			nil,
+			[]MacAddress{mac2},
+		},
+	}
+	macs := func(entries []L2ACLEntry) []MacAddress {
+		var out []MacAddress
+		for _, entry := range entries {
+			out = append(out, entry.Mac)
+		}
+		return out
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := tt.acl
+			assert.Equal(t, tt.wantChanged, tt.edit(&acl))
+			assert.Equal(t, tt.wantAccept, macs(acl.Accept))
+			assert.Equal(t, tt.wantDeny, macs(acl.Deny))
+		})
+	}
+}
+
+func TestEditL2ACL(t *testing.T) {
+	mac1 := mustMac("00:00:00:00:00:01")
+	mac2 := mustMac("00:00:00:00:00:02")
+
+	tests := []struct {
+		name string
+		// reads are the ACLs returned by successive reads; then reads return the last read or update
+		reads       []L2ACL
+		wantUpdates []L2ACL
+		err         string
+	}{
+		{
+			"unchanged",
+			[]L2ACL{{ID: 1, DefaultMode: "deny"}},
+			[]L2ACL{{ID: 1, DefaultMode: "deny", Accept: []L2ACLEntry{{mac1}}}},
+			"",
+		},
+		{
+			"already present",
+			[]L2ACL{{ID: 1, DefaultMode: "deny", Accept: []L2ACLEntry{{mac1}}}},
+			nil,
+			"",
+		},
+		{
+			"changed between reads",
+			[]L2ACL{
+				{ID: 1, DefaultMode: "deny"},
+				{ID: 1, DefaultMode: "deny", Accept: []L2ACLEntry{{mac2}}},
+			},
+			[]L2ACL{{ID: 1, DefaultMode: "deny", Accept: []L2ACLEntry{{mac2}, {mac1}}}},
+			"",
+		},
+		{
+			"write lost",
+			[]L2ACL{
+				{ID: 1, DefaultMode: "deny"},
+				{ID: 1, DefaultMode: "deny"},
+				{ID: 1, DefaultMode: "deny", Description: "other"},
+			},
+			[]L2ACL{
+				{ID: 1, DefaultMode: "deny", Accept: []L2ACLEntry{{mac1}}},
+				{ID: 1, DefaultMode: "deny", Description: "other", Accept: []L2ACLEntry{{mac1}}},
+			},
+			"",
+		},
+		{
+			"keeps changing",
+			[]L2ACL{
+				{ID: 1, DefaultMode: "deny"},
+				{ID: 1, DefaultMode: "deny", Description: "a"},
+				{ID: 1, DefaultMode: "deny", Description: "b"},
+				{ID: 1, DefaultMode: "deny", Description: "c"},
+			},
+			nil,
+			"L2 ACL 1 changed during 3 attempts to edit it",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reads := 0
+			device := tt.reads[0]
+			get := func() (*L2ACL, error) {
+				if reads < len(tt.reads) {
+					device = tt.reads[reads]
+				}
+				reads++
+				acl := device
+				return &acl, nil
+			}
+			var updates []L2ACL
+			update := func(acl L2ACL) error {
+				updates = append(updates, acl)
+				device = acl
+				return nil
+			}
+
+			err := editL2ACL(get, update, func(acl *L2ACL) bool { return acl.addMac(mac1) })
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantUpdates, updates)
+		})
+	}
+}
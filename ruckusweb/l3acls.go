@@ -0,0 +1,285 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// MaxL3ACLRules is the maximum number of rules in an L3ACL.
+const MaxL3ACLRules = 32
+
+// L3ACLs manages L3/L4 access control policies. IPv4 and IPv6 policies are separate: see Client.L3ACLs and
+// Client.L3ACLsIPv6.
+type L3ACLs struct {
+	c    *Client
+	ipv6 bool
+}
+
+// L3ACLs returns a handle to IPv4 policies, which Wlans refer to by Wlan.PolicyID.
+func (c *Client) L3ACLs() L3ACLs {
+	return L3ACLs{c, false}
+}
+
+// L3ACLsIPv6 returns a handle to IPv6 policies, which Wlans refer to by Wlan.Policy6ID.
+func (c *Client) L3ACLsIPv6() L3ACLs {
+	return L3ACLs{c, true}
+}
+
+// L3ACL is an ordered list of rules admitting or dropping traffic by address, protocol and port. Traffic matching no
+// rule is handled according to DefaultMode.
+type L3ACL struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	// DefaultMode is "allow" or "deny".
+	DefaultMode string `xml:"default-mode,attr"`
+	// Editable is false for the built-in policies.
+	Editable bool `xml:"EDITABLE,attr,omitempty"`
+
+	Rules []L3ACLRule `xml:"rule"`
+}
+
+// L3ACLRule matches traffic. Empty fields match anything.
+type L3ACLRule struct {
+	// Order is the rule's 1-based position. Rules are evaluated in order and the first match wins.
+	Order       int    `xml:"order,attr"`
+	Description string `xml:"description,attr"`
+	// Action is "accept" or "deny".
+	Action string `xml:"action,attr"`
+	// Protocol is an IP protocol number, e.g. "6" for TCP or "17" for UDP.
+	Protocol string `xml:"protocol,attr"`
+
+	// SrcAddr and DstAddr are an address or CIDR prefix in the policy's address family, or "Any".
+	SrcAddr string `xml:"src-addr,attr"`
+	DstAddr string `xml:"dst-addr,attr"`
+	// SrcPort and DstPort are a port, e.g. "443", or a range, e.g. "8000-8080". They require Protocol to be TCP or
+	// UDP.
+	SrcPort string `xml:"src-port,attr"`
+	DstPort string `xml:"dst-port,attr"`
+}
+
+// Renumber sets each rule's Order to its position in Rules.
+func (a *L3ACL) Renumber() {
+	for i := range a.Rules {
+		a.Rules[i].Order = i + 1
+	}
+}
+
+func (a L3ACL) validate(ipv6 bool) error {
+	if len(a.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if a.DefaultMode != "allow" && a.DefaultMode != "deny" {
+		return fmt.Errorf("default mode must be \"allow\" or \"deny\", not %q", a.DefaultMode)
+	}
+	if len(a.Rules) > MaxL3ACLRules {
+		return fmt.Errorf("too many rules: %d > %d", len(a.Rules), MaxL3ACLRules)
+	}
+	for i, rule := range a.Rules {
+		if rule.Order != i+1 {
+			return fmt.Errorf("rule %d has order %d; rules must be numbered consecutively from 1", i+1, rule.Order)
+		}
+		if err := rule.validate(ipv6); err != nil {
+			return fmt.Errorf("rule %d: %v", rule.Order, err)
+		}
+	}
+	return nil
+}
+
+func (r L3ACLRule) validate(ipv6 bool) error {
+	if r.Action != "accept" && r.Action != "deny" {
+		return fmt.Errorf("action must be \"accept\" or \"deny\", not %q", r.Action)
+	}
+
+	if r.Protocol != "" {
+		if n, err := strconv.Atoi(r.Protocol); err != nil || n < 0 || n > 255 {
+			return fmt.Errorf("invalid protocol %q", r.Protocol)
+		}
+	}
+
+	for _, addr := range []struct{ name, value string }{{"source", r.SrcAddr}, {"destination", r.DstAddr}} {
+		if err := validL3ACLAddr(addr.value, ipv6); err != nil {
+			return fmt.Errorf("invalid %s address: %v", addr.name, err)
+		}
+	}
+
+	for _, port := range []struct{ name, value string }{{"source", r.SrcPort}, {"destination", r.DstPort}} {
+		if port.value == "" {
+			continue
+		}
+		if r.Protocol != "6" && r.Protocol != "17" {
+			return fmt.Errorf("%s port requires TCP or UDP", port.name)
+		}
+		if err := validL3ACLPort(port.value); err != nil {
+			return fmt.Errorf("invalid %s port: %v", port.name, err)
+		}
+	}
+	return nil
+}
+
+func validL3ACLAddr(s string, ipv6 bool) error {
+	if s == "" || strings.EqualFold(s, "any") {
+		return nil
+	}
+
+	var addr netip.Addr
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		addr = prefix.Addr()
+	} else if addr, err = netip.ParseAddr(s); err != nil {
+		return fmt.Errorf("%q is not an address or CIDR prefix", s)
+	}
+
+	if ipv6 && !addr.Is6() {
+		return fmt.Errorf("%q is not an IPv6 address", s)
+	} else if !ipv6 && !addr.Is4() {
+		return fmt.Errorf("%q is not an IPv4 address", s)
+	}
+	return nil
+}
+
+func validL3ACLPort(s string) error {
+	low, high, isRange := strings.Cut(s, "-")
+	lowPort, err := strconv.ParseUint(low, 10, 16)
+	if err != nil || lowPort == 0 {
+		return fmt.Errorf("%q is not a port or port range", s)
+	}
+	if isRange {
+		highPort, err := strconv.ParseUint(high, 10, 16)
+		if err != nil || highPort < lowPort {
+			return fmt.Errorf("%q is not a port or port range", s)
+		}
+	}
+	return nil
+}
+
+func (l L3ACLs) comp() string {
+	if l.ipv6 {
+		return "policy6-list"
+	}
+	return "policy-list"
+}
+
+func (l L3ACLs) element() string {
+	if l.ipv6 {
+		return "policy6"
+	}
+	return "policy"
+}
+
+func (l L3ACLs) List(ctx context.Context) ([]L3ACL, error) {
+	var resp struct {
+		XMLName  xml.Name
+		Policies []L3ACL `xml:",any"`
+	}
+
+	if err := l.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     l.comp(),
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Policies, nil
+	}
+}
+
+// Get returns the L3ACL with the given ID.
+func (l L3ACLs) Get(ctx context.Context, id int) (*L3ACL, error) {
+	acls, err := l.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range acls {
+		if acls[i].ID == id {
+			return &acls[i], nil
+		}
+	}
+	return nil, fmt.Errorf("L3 ACL %d not found", id)
+}
+
+// l3aclElement encodes an L3ACL as a policy or policy6 element.
+type l3aclElement struct {
+	XMLName xml.Name
+	L3ACL
+}
+
+func (l L3ACLs) wrap(acl L3ACL) l3aclElement {
+	acl.Editable = false
+	return l3aclElement{xml.Name{Local: l.element()}, acl}
+}
+
+// Create creates an L3ACL, returning the created record.
+func (l L3ACLs) Create(ctx context.Context, acl L3ACL) (*L3ACL, error) {
+	req := l.wrap(acl)
+	req.L3ACL.ID = 0 // ensure we don't specify one
+
+	if err := req.L3ACL.validate(l.ipv6); err != nil {
+		return nil, fmt.Errorf("invalid L3ACL: %v", err)
+	}
+
+	var resp l3aclElement
+	if err := l.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   l.comp(),
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.L3ACL, nil
+	}
+}
+
+// Update updates an L3ACL, replacing the record.
+func (l L3ACLs) Update(ctx context.Context, acl L3ACL) error {
+	req := l.wrap(acl)
+
+	if err := req.L3ACL.validate(l.ipv6); err != nil {
+		return fmt.Errorf("invalid L3ACL: %v", err)
+	}
+
+	return l.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   l.comp(),
+	}, &req, nil)
+}
+
+// Delete an L3ACL by ID. It must not be bound to any Wlan.
+func (l L3ACLs) Delete(ctx context.Context, id int) error {
+	req := struct {
+		XMLName xml.Name
+		ID      int `xml:"id,attr"`
+	}{xml.Name{Local: l.element()}, id}
+
+	return l.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   l.comp(),
+	}, &req, nil)
+}
+
+// Bind applies the L3ACL with the given ID to the named Wlan.
+func (l L3ACLs) Bind(ctx context.Context, id int, wlanName string) error {
+	return l.bind(ctx, wlanName, strconv.Itoa(id))
+}
+
+// Unbind removes any L3ACL of this address family from the named Wlan.
+func (l L3ACLs) Unbind(ctx context.Context, wlanName string) error {
+	return l.bind(ctx, wlanName, "")
+}
+
+func (l L3ACLs) bind(ctx context.Context, wlanName string, policyID string) error {
+	return l.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		field := &wlan.PolicyID
+		if l.ipv6 {
+			field = &wlan.Policy6ID
+		}
+		if *field == policyID {
+			return false
+		}
+		*field = policyID
+		return true
+	})
+}
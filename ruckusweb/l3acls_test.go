@@ -0,0 +1,92 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestL3ACLValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		ipv6  bool
+		rules []L3ACLRule
+		want  string
+	}{
+		{
+			"empty",
+			false,
+			nil,
+			"",
+		},
+		{
+			"valid",
+			false,
+			[]L3ACLRule{
+				{Order: 1, Action: "accept", Protocol: "17", DstAddr: "10.0.0.53", DstPort: "53"},
+				{Order: 2, Action: "deny", SrcAddr: "Any", DstAddr: "10.0.0.0/8"},
+				{Order: 3, Action: "accept", Protocol: "6", DstPort: "8000-8080"},
+			},
+			"",
+		},
+		{
+			"valid ipv6",
+			true,
+			[]L3ACLRule{{Order: 1, Action: "deny", DstAddr: "fd00::/8"}},
+			"",
+		},
+		{
+			"misordered",
+			false,
+			[]L3ACLRule{{Order: 1, Action: "accept"}, {Order: 3, Action: "accept"}},
+			"rule 2 has order 3; rules must be numbered consecutively from 1",
+		},
+		{
+			"wrong family",
+			true,
+			[]L3ACLRule{{Order: 1, Action: "deny", DstAddr: "10.0.0.0/8"}},
+			`rule 1: invalid destination address: "10.0.0.0/8" is not an IPv6 address`,
+		},
+		{
+			"port without protocol",
+			false,
+			[]L3ACLRule{{Order: 1, Action: "deny", DstPort: "80"}},
+			"rule 1: destination port requires TCP or UDP",
+		},
+		{
+			"backwards port range",
+			false,
+			[]L3ACLRule{{Order: 1, Action: "deny", Protocol: "6", SrcPort: "90-80"}},
+			`rule 1: invalid source port: "90-80" is not a port or port range`,
+		},
+		{
+			"invalid action",
+			false,
+			[]L3ACLRule{{Order: 1, Action: "drop"}},
+			`rule 1: action must be "accept" or "deny", not "drop"`,
+		},
+		{
+			"too many rules",
+			false,
+			make([]L3ACLRule, MaxL3ACLRules+1),
+			"too many rules: 33 > 32",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acl := L3ACL{Name: "test", DefaultMode: "allow", Rules: tt.rules}
+			err := acl.validate(tt.ipv6)
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestL3ACLRenumber(t *testing.T) {
+	acl := L3ACL{Name: "test", DefaultMode: "deny", Rules: []L3ACLRule{{Order: 5, Action: "accept"}, {Order: 2, Action: "accept"}}}
+	acl.Renumber()
+	assert.NoError(t, acl.validate(false))
+}
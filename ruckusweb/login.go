@@ -124,3 +124,11 @@ func (c *Client) doLogin(ctx context.Context) (*loginResult, error) {
 
 	return &result, nil
 }
+
+// resetLogin discards the current session so that the next request logs in again. This is needed after the device
+// restarts, since its old session cookie is no longer valid but is still present in the jar.
+func (c *Client) resetLogin() {
+	c.m.Lock()
+	c.loginResult = loginResult{}
+	c.m.Unlock()
+}
@@ -0,0 +1,424 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PrecedenceSource is a source of per-station settings ranked by a PrecedencePolicy.
+type PrecedenceSource int
+
+const (
+	// PrecedenceSourceAAA is the attributes returned by the RADIUS server which authenticated the station.
+	PrecedenceSourceAAA PrecedenceSource = iota
+	// PrecedenceSourceDevicePolicy is the matching rule of the Wlan's DevicePolicy.
+	PrecedenceSourceDevicePolicy
+	// PrecedenceSourceWlan is the Wlan's own settings.
+	PrecedenceSourceWlan
+)
+
+var precedenceSources = []PrecedenceSource{PrecedenceSourceAAA, PrecedenceSourceDevicePolicy, PrecedenceSourceWlan}
+
+func (s PrecedenceSource) String() string {
+	switch s {
+	case PrecedenceSourceAAA:
+		return "AAA"
+	case PrecedenceSourceDevicePolicy:
+		return "Device Policy"
+	case PrecedenceSourceWlan:
+		return "WLAN"
+	default:
+		return fmt.Sprintf("PrecedenceSource(%d)", int(s))
+	}
+}
+
+func (s PrecedenceSource) MarshalText() ([]byte, error) {
+	switch s {
+	case PrecedenceSourceAAA, PrecedenceSourceDevicePolicy, PrecedenceSourceWlan:
+		return []byte(s.String()), nil
+	default:
+		return nil, fmt.Errorf("invalid PrecedenceSource %d", int(s))
+	}
+}
+
+func (s *PrecedenceSource) UnmarshalText(text []byte) error {
+	for _, source := range precedenceSources {
+		if strings.EqualFold(string(text), source.String()) {
+			*s = source
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid precedence source: %q", string(text))
+}
+
+// PrecedenceOrder ranks every PrecedenceSource, highest precedence first. It is encoded as a comma-separated list like
+// "AAA,Device Policy,WLAN".
+type PrecedenceOrder []PrecedenceSource
+
+func (o PrecedenceOrder) MarshalText() ([]byte, error) {
+	var b []byte
+	for i, source := range o {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		text, err := source.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, text...)
+	}
+	return b, nil
+}
+
+func (o *PrecedenceOrder) UnmarshalText(text []byte) error {
+	var out PrecedenceOrder
+	if len(text) > 0 {
+		for _, part := range strings.Split(string(text), ",") {
+			var source PrecedenceSource
+			if err := source.UnmarshalText([]byte(strings.TrimSpace(part))); err != nil {
+				return err
+			}
+			out = append(out, source)
+		}
+	}
+	*o = out
+	return nil
+}
+
+func (o PrecedenceOrder) validate() error {
+	if len(o) != len(precedenceSources) {
+		return fmt.Errorf("must rank all of %s", PrecedenceOrder(precedenceSources))
+	}
+	seen := make(map[PrecedenceSource]bool)
+	for _, source := range o {
+		if source < PrecedenceSourceAAA || source > PrecedenceSourceWlan {
+			return fmt.Errorf("invalid source %d", int(source))
+		} else if seen[source] {
+			return fmt.Errorf("duplicate source %s", source)
+		}
+		seen[source] = true
+	}
+	return nil
+}
+
+func (o PrecedenceOrder) String() string {
+	names := make([]string, len(o))
+	for i, source := range o {
+		names[i] = source.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+// DefaultPrecedencePolicyID is the ID of the built-in PrecedencePolicy, which cannot be deleted.
+const DefaultPrecedencePolicyID = 1
+
+// PrecedencePolicies manages precedence policies, which decide which source provides a station's VLAN and rate limit
+// when several of them set one. Wlans refer to them by Wlan.PrecedenceID.
+type PrecedencePolicies struct {
+	c *Client
+}
+
+func (c *Client) PrecedencePolicies() PrecedencePolicies {
+	return PrecedencePolicies{c}
+}
+
+type PrecedencePolicy struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	Vlan      PrecedenceOrder `xml:"vlan,attr"`
+	RateLimit PrecedenceOrder `xml:"rate-limit,attr"`
+}
+
+func (p PrecedencePolicy) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if err := p.Vlan.validate(); err != nil {
+		return fmt.Errorf("VLAN order: %v", err)
+	}
+	if err := p.RateLimit.validate(); err != nil {
+		return fmt.Errorf("rate limit order: %v", err)
+	}
+	return nil
+}
+
+func (p PrecedencePolicies) List(ctx context.Context) ([]PrecedencePolicy, error) {
+	var resp struct {
+		XMLName  xml.Name           `xml:"precedence-list"`
+		Policies []PrecedencePolicy `xml:"precedence"`
+	}
+
+	if err := p.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "precedence-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Policies, nil
+	}
+}
+
+// Create creates a PrecedencePolicy, returning the created record.
+func (p PrecedencePolicies) Create(ctx context.Context, policy PrecedencePolicy) (*PrecedencePolicy, error) {
+	var req struct {
+		XMLName xml.Name `xml:"precedence"`
+		PrecedencePolicy
+	}
+	req.PrecedencePolicy = policy
+	req.PrecedencePolicy.ID = 0 // ensure we don't specify one
+
+	if err := req.PrecedencePolicy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid PrecedencePolicy: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"precedence"`
+		PrecedencePolicy
+	}
+
+	if err := p.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "precedence-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.PrecedencePolicy, nil
+	}
+}
+
+// Update updates a PrecedencePolicy, replacing the record.
+func (p PrecedencePolicies) Update(ctx context.Context, policy PrecedencePolicy) error {
+	req := struct {
+		XMLName xml.Name `xml:"precedence"`
+		PrecedencePolicy
+	}{
+		PrecedencePolicy: policy,
+	}
+
+	if err := req.PrecedencePolicy.validate(); err != nil {
+		return fmt.Errorf("invalid PrecedencePolicy: %v", err)
+	}
+
+	return p.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "precedence-list",
+	}, &req, nil)
+}
+
+// Delete a PrecedencePolicy by ID. It must not be in use by any Wlan.
+func (p PrecedencePolicies) Delete(ctx context.Context, id int) error {
+	if id == DefaultPrecedencePolicyID {
+		return errors.New("the default precedence policy cannot be deleted")
+	}
+
+	var req struct {
+		XMLName xml.Name `xml:"precedence"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return p.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "precedence-list",
+	}, &req, nil)
+}
+
+// Bind applies the PrecedencePolicy with the given ID to the named Wlan.
+func (p PrecedencePolicies) Bind(ctx context.Context, id int, wlanName string) error {
+	return p.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.PrecedenceID == id {
+			return false
+		}
+		wlan.PrecedenceID = id
+		return true
+	})
+}
+
+// Unbind returns the named Wlan to the default PrecedencePolicy.
+func (p PrecedencePolicies) Unbind(ctx context.Context, wlanName string) error {
+	return p.Bind(ctx, DefaultPrecedencePolicyID, wlanName)
+}
+
+// PrecedenceDecision explains which source provided one of a station's settings.
+type PrecedenceDecision struct {
+	// Applied is false if no source set anything, e.g. because the station has no rate limit.
+	Applied bool
+	// Source provided the setting, if Applied.
+	Source PrecedenceSource
+	// Value describes the setting, e.g. "VLAN 20".
+	Value string
+	// Steps explains what each source offered, in precedence order.
+	Steps []string
+}
+
+// StationPrecedence explains where a station's VLAN and rate limit came from.
+type StationPrecedence struct {
+	Station   Station
+	Policy    PrecedencePolicy
+	Vlan      PrecedenceDecision
+	RateLimit PrecedenceDecision
+}
+
+// Explain resolves the precedence policy of station's Wlan, reporting which source provided its VLAN and rate limit.
+//
+// The controller does not report the attributes an AAA server returned. A VLAN is attributed to AAA when the Wlan has
+// dynamic VLAN enabled and the station is on a VLAN no other source offered. AAA rate limits cannot be detected, so
+// they are noted but never reported as applied.
+func (p PrecedencePolicies) Explain(ctx context.Context, station Station) (*StationPrecedence, error) {
+	wlans, err := p.c.Wlans().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var wlan *Wlan
+	for i := range wlans {
+		if wlans[i].ID == station.WlanID {
+			wlan = &wlans[i]
+			break
+		}
+	}
+	if wlan == nil {
+		return nil, fmt.Errorf("WLAN %d not found", station.WlanID)
+	}
+
+	policies, err := p.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var policy *PrecedencePolicy
+	for i := range policies {
+		if policies[i].ID == wlan.PrecedenceID {
+			policy = &policies[i]
+			break
+		}
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("precedence policy %d not found", wlan.PrecedenceID)
+	}
+
+	var devicePolicy *DevicePolicy
+	if wlan.DevicepolicyID != "" {
+		id, err := strconv.Atoi(wlan.DevicepolicyID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device policy ID %q", wlan.DevicepolicyID)
+		}
+		devicePolicies, err := p.c.DevicePolicies().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range devicePolicies {
+			if devicePolicies[i].ID == id {
+				devicePolicy = &devicePolicies[i]
+				break
+			}
+		}
+		if devicePolicy == nil {
+			return nil, fmt.Errorf("device policy %d not found", id)
+		}
+	}
+
+	resolved := resolvePrecedence(*policy, *wlan, devicePolicy, station)
+	return &resolved, nil
+}
+
+// precedenceOffer is what one source offers for a setting. Sources which offer nothing have an empty value and
+// explain why in reason.
+type precedenceOffer struct {
+	value  string
+	reason string
+}
+
+func resolvePrecedence(policy PrecedencePolicy, wlan Wlan, devicePolicy *DevicePolicy, station Station) StationPrecedence {
+	authenticated := station.User != "" && wlan.Authentication != WlanAuthenticationOpen
+
+	var rule *DevicePolicyRule
+	if devicePolicy != nil {
+		rule = devicePolicy.Match(station)
+	}
+	deviceOffer := func(set bool, value string) precedenceOffer {
+		switch {
+		case devicePolicy == nil:
+			return precedenceOffer{reason: "no device policy on WLAN"}
+		case rule == nil:
+			return precedenceOffer{reason: fmt.Sprintf("no rule of %q matches", devicePolicy.Name)}
+		case !set:
+			return precedenceOffer{reason: fmt.Sprintf("rule %d of %q sets nothing", rule.Order, devicePolicy.Name)}
+		default:
+			return precedenceOffer{value: value, reason: fmt.Sprintf("rule %d of %q", rule.Order, devicePolicy.Name)}
+		}
+	}
+
+	vlanOffers := map[PrecedenceSource]precedenceOffer{
+		PrecedenceSourceWlan: {value: fmt.Sprintf("VLAN %d", wlan.VlanID), reason: "WLAN VLAN"},
+	}
+	if rule != nil {
+		vlanOffers[PrecedenceSourceDevicePolicy] = deviceOffer(rule.Vlan != 0, fmt.Sprintf("VLAN %d", rule.Vlan))
+	} else {
+		vlanOffers[PrecedenceSourceDevicePolicy] = deviceOffer(false, "")
+	}
+	switch {
+	case !bool(wlan.Dvlan):
+		vlanOffers[PrecedenceSourceAAA] = precedenceOffer{reason: "dynamic VLAN disabled on WLAN"}
+	case !authenticated:
+		vlanOffers[PrecedenceSourceAAA] = precedenceOffer{reason: "station not authenticated by AAA"}
+	case station.Vlan == 0 || station.Vlan == wlan.VlanID || (rule != nil && station.Vlan == rule.Vlan):
+		vlanOffers[PrecedenceSourceAAA] = precedenceOffer{reason: "no VLAN assigned by AAA server"}
+	default:
+		vlanOffers[PrecedenceSourceAAA] = precedenceOffer{value: fmt.Sprintf("VLAN %d", station.Vlan), reason: "assigned by AAA server"}
+	}
+
+	rateOffers := map[PrecedenceSource]precedenceOffer{}
+	if up, down := wlan.Qos.UplinkPreset, wlan.Qos.DownlinkPreset; !up.Unlimited() || !down.Unlimited() {
+		rateOffers[PrecedenceSourceWlan] = precedenceOffer{value: fmt.Sprintf("uplink %s, downlink %s", up, down), reason: "WLAN rate limit"}
+	} else {
+		rateOffers[PrecedenceSourceWlan] = precedenceOffer{reason: "WLAN has no rate limit"}
+	}
+	if rule != nil {
+		rateOffers[PrecedenceSourceDevicePolicy] = deviceOffer(rule.UplinkKbps != 0 || rule.DownlinkKbps != 0,
+			fmt.Sprintf("uplink %s, downlink %s", kbpsString(rule.UplinkKbps), kbpsString(rule.DownlinkKbps)))
+	} else {
+		rateOffers[PrecedenceSourceDevicePolicy] = deviceOffer(false, "")
+	}
+	if authenticated {
+		rateOffers[PrecedenceSourceAAA] = precedenceOffer{reason: "AAA rate limits are not reported; any returned by the server take effect here"}
+	} else {
+		rateOffers[PrecedenceSourceAAA] = precedenceOffer{reason: "station not authenticated by AAA"}
+	}
+
+	return StationPrecedence{
+		Station:   station,
+		Policy:    policy,
+		Vlan:      decidePrecedence(policy.Vlan, vlanOffers),
+		RateLimit: decidePrecedence(policy.RateLimit, rateOffers),
+	}
+}
+
+func decidePrecedence(order PrecedenceOrder, offers map[PrecedenceSource]precedenceOffer) PrecedenceDecision {
+	var d PrecedenceDecision
+	for _, source := range order {
+		offer := offers[source]
+		switch {
+		case offer.value == "":
+			d.Steps = append(d.Steps, fmt.Sprintf("%s: nothing (%s)", source, offer.reason))
+		case d.Applied:
+			d.Steps = append(d.Steps, fmt.Sprintf("%s: %s (%s), overridden by %s", source, offer.value, offer.reason, d.Source))
+		default:
+			d.Applied, d.Source, d.Value = true, source, offer.value
+			d.Steps = append(d.Steps, fmt.Sprintf("%s: %s (%s), applied", source, offer.value, offer.reason))
+		}
+	}
+	return d
+}
+
+func kbpsString(kbps int) string {
+	if kbps == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d kbps", kbps)
+}
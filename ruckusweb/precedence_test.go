@@ -0,0 +1,136 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePrecedence(t *testing.T) {
+	defaultPolicy := PrecedencePolicy{
+		ID:        DefaultPrecedencePolicyID,
+		Name:      "Default",
+		Vlan:      PrecedenceOrder{PrecedenceSourceAAA, PrecedenceSourceDevicePolicy, PrecedenceSourceWlan},
+		RateLimit: PrecedenceOrder{PrecedenceSourceAAA, PrecedenceSourceDevicePolicy, PrecedenceSourceWlan},
+	}
+	devicePolicy := &DevicePolicy{
+		Name: "devices",
+		Rules: []DevicePolicyRule{
+			{Order: 1, OsType: "Gaming", Vlan: 40, DownlinkKbps: 5000},
+			{Order: 2, DeviceType: "Smartphone", UplinkKbps: 1000},
+		},
+	}
+	wlan := NewWlan("test")
+	wlan.VlanID = 10
+
+	tests := []struct {
+		name         string
+		policy       PrecedencePolicy
+		wlan         func(*Wlan)
+		devicePolicy *DevicePolicy
+		station      Station
+		vlan         PrecedenceDecision
+		rateLimit    PrecedenceDecision
+	}{
+		{
+			name:    "wlan only",
+			policy:  defaultPolicy,
+			station: Station{Vlan: 10},
+			vlan: PrecedenceDecision{Applied: true, Source: PrecedenceSourceWlan, Value: "VLAN 10", Steps: []string{
+				"AAA: nothing (dynamic VLAN disabled on WLAN)",
+				"Device Policy: nothing (no device policy on WLAN)",
+				"WLAN: VLAN 10 (WLAN VLAN), applied",
+			}},
+			rateLimit: PrecedenceDecision{Steps: []string{
+				"AAA: nothing (station not authenticated by AAA)",
+				"Device Policy: nothing (no device policy on WLAN)",
+				"WLAN: nothing (WLAN has no rate limit)",
+			}},
+		},
+		{
+			name:         "device policy",
+			policy:       defaultPolicy,
+			wlan:         func(w *Wlan) { w.Qos.DownlinkPreset = RateLimit10Mbps },
+			devicePolicy: devicePolicy,
+			station:      Station{DeviceInfo: "Gaming", Vlan: 40},
+			vlan: PrecedenceDecision{Applied: true, Source: PrecedenceSourceDevicePolicy, Value: "VLAN 40", Steps: []string{
+				"AAA: nothing (dynamic VLAN disabled on WLAN)",
+				`Device Policy: VLAN 40 (rule 1 of "devices"), applied`,
+				"WLAN: VLAN 10 (WLAN VLAN), overridden by Device Policy",
+			}},
+			rateLimit: PrecedenceDecision{Applied: true, Source: PrecedenceSourceDevicePolicy, Value: "uplink unlimited, downlink 5000 kbps", Steps: []string{
+				"AAA: nothing (station not authenticated by AAA)",
+				`Device Policy: uplink unlimited, downlink 5000 kbps (rule 1 of "devices"), applied`,
+				"WLAN: uplink unlimited, downlink 10 Mbps (WLAN rate limit), overridden by Device Policy",
+			}},
+		},
+		{
+			name: "wlan first",
+			policy: PrecedencePolicy{
+				Name:      "wlan first",
+				Vlan:      PrecedenceOrder{PrecedenceSourceWlan, PrecedenceSourceAAA, PrecedenceSourceDevicePolicy},
+				RateLimit: PrecedenceOrder{PrecedenceSourceWlan, PrecedenceSourceAAA, PrecedenceSourceDevicePolicy},
+			},
+			devicePolicy: devicePolicy,
+			station:      Station{DeviceType: "Smartphone", Vlan: 10},
+			vlan: PrecedenceDecision{Applied: true, Source: PrecedenceSourceWlan, Value: "VLAN 10", Steps: []string{
+				"WLAN: VLAN 10 (WLAN VLAN), applied",
+				"AAA: nothing (dynamic VLAN disabled on WLAN)",
+				`Device Policy: nothing (rule 2 of "devices" sets nothing)`,
+			}},
+			rateLimit: PrecedenceDecision{Applied: true, Source: PrecedenceSourceDevicePolicy, Value: "uplink 1000 kbps, downlink unlimited", Steps: []string{
+				"WLAN: nothing (WLAN has no rate limit)",
+				"AAA: nothing (station not authenticated by AAA)",
+				`Device Policy: uplink 1000 kbps, downlink unlimited (rule 2 of "devices"), applied`,
+			}},
+		},
+		{
+			name:   "aaa",
+			policy: defaultPolicy,
+			wlan: func(w *Wlan) {
+				w.Authentication = WlanAuthentication8021xEAP
+				w.Dvlan = true
+			},
+			devicePolicy: devicePolicy,
+			station:      Station{User: "alice", DeviceInfo: "Windows 11", Vlan: 20},
+			vlan: PrecedenceDecision{Applied: true, Source: PrecedenceSourceAAA, Value: "VLAN 20", Steps: []string{
+				"AAA: VLAN 20 (assigned by AAA server), applied",
+				`Device Policy: nothing (no rule of "devices" matches)`,
+				"WLAN: VLAN 10 (WLAN VLAN), overridden by AAA",
+			}},
+			rateLimit: PrecedenceDecision{Steps: []string{
+				"AAA: nothing (AAA rate limits are not reported; any returned by the server take effect here)",
+				`Device Policy: nothing (no rule of "devices" matches)`,
+				"WLAN: nothing (WLAN has no rate limit)",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := wlan
+			if tt.wlan != nil {
+				tt.wlan(&w)
+			}
+			got := resolvePrecedence(tt.policy, w, tt.devicePolicy, tt.station)
+			assert.Equal(t, tt.vlan, got.Vlan)
+			assert.Equal(t, tt.rateLimit, got.RateLimit)
+		})
+	}
+}
+
+func TestPrecedenceOrder(t *testing.T) {
+	var o PrecedenceOrder
+	assert.NoError(t, o.UnmarshalText([]byte("WLAN,AAA,Device Policy")))
+	assert.Equal(t, PrecedenceOrder{PrecedenceSourceWlan, PrecedenceSourceAAA, PrecedenceSourceDevicePolicy}, o)
+	assert.NoError(t, o.validate())
+
+	text, err := o.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "WLAN,AAA,Device Policy", string(text))
+
+	assert.EqualError(t, PrecedenceOrder{PrecedenceSourceAAA, PrecedenceSourceAAA, PrecedenceSourceWlan}.validate(), "duplicate source AAA")
+	assert.EqualError(t, PrecedenceOrder{PrecedenceSourceAAA}.validate(), "must rank all of AAA, Device Policy, WLAN")
+
+	_, err = PrecedenceOrder{PrecedenceSourceWlan, PrecedenceSource(9)}.MarshalText()
+	assert.EqualError(t, err, "invalid PrecedenceSource 9")
+}
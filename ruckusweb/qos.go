@@ -0,0 +1,185 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RateLimit is a per-station throughput limit as the firmware encodes it, e.g. "250kbps" or "10mbps", or "DISABLE" for
+// no limit. Values read from the device are kept as read, so that they are written back unchanged even if they are not
+// one of the constants below.
+//
+// The firmware only accepts RateLimitUnlimited and RateLimitPresets when a limit is set.
+type RateLimit string
+
+const (
+	RateLimitUnlimited RateLimit = "DISABLE"
+	RateLimit100Kbps   RateLimit = "100kbps"
+	RateLimit250Kbps   RateLimit = "250kbps"
+	RateLimit500Kbps   RateLimit = "500kbps"
+	RateLimit1Mbps     RateLimit = "1mbps"
+	RateLimit2Mbps     RateLimit = "2mbps"
+	RateLimit5Mbps     RateLimit = "5mbps"
+	RateLimit10Mbps    RateLimit = "10mbps"
+	RateLimit20Mbps    RateLimit = "20mbps"
+	RateLimit50Mbps    RateLimit = "50mbps"
+)
+
+// RateLimitPresets lists the limited RateLimit values accepted by the firmware, slowest first.
+var RateLimitPresets = []RateLimit{
+	RateLimit100Kbps, RateLimit250Kbps, RateLimit500Kbps,
+	RateLimit1Mbps, RateLimit2Mbps, RateLimit5Mbps,
+	RateLimit10Mbps, RateLimit20Mbps, RateLimit50Mbps,
+}
+
+func (r RateLimit) validate() error {
+	if r.Unlimited() {
+		return nil
+	}
+	for _, preset := range RateLimitPresets {
+		if r == preset {
+			return nil
+		}
+	}
+	return fmt.Errorf("rate limit %q is not one of the presets", string(r))
+}
+
+// Unlimited returns true if r imposes no limit. An empty RateLimit, as read from a Wlan without QoS settings, is
+// unlimited.
+func (r RateLimit) Unlimited() bool {
+	return r == "" || strings.EqualFold(string(r), string(RateLimitUnlimited))
+}
+
+// parse splits a limited RateLimit into its number and unit, e.g. "0.5" and "Mbps".
+func (r RateLimit) parse() (number float64, text string, unit string, ok bool) {
+	s := strings.TrimSpace(string(r))
+	lower := strings.ToLower(s)
+	for _, u := range []string{"kbps", "Mbps"} {
+		if strings.HasSuffix(lower, strings.ToLower(u)) {
+			text = strings.TrimSpace(s[:len(s)-len(u)])
+			number, err := strconv.ParseFloat(text, 64)
+			if err != nil || number <= 0 {
+				return 0, "", "", false
+			}
+			return number, text, u, true
+		}
+	}
+	return 0, "", "", false
+}
+
+// Kbps returns the limit in kbps, or 0 if r is unlimited. ok is false if r is not in a format this package recognizes.
+func (r RateLimit) Kbps() (kbps int, ok bool) {
+	if r.Unlimited() {
+		return 0, true
+	}
+	number, _, unit, ok := r.parse()
+	if !ok {
+		return 0, false
+	}
+	if unit == "Mbps" {
+		number *= 1000
+	}
+	return int(math.Round(number)), true
+}
+
+// String returns a human-readable rate, e.g. "250 kbps", "10 Mbps" or "unlimited". Unrecognized values are returned
+// as read.
+func (r RateLimit) String() string {
+	if r.Unlimited() {
+		return "unlimited"
+	}
+	if _, text, unit, ok := r.parse(); ok {
+		return text + " " + unit
+	}
+	return string(r)
+}
+
+// MaxPerSsidRateLimitMbps is the highest per-SSID rate limit, in Mbps, accepted by WlanQos.
+const MaxPerSsidRateLimitMbps = 200
+
+// validate rejects settings which would be unsafe to send to the device: rate limits in an unrecognized format and
+// per-SSID limits out of range. It accepts well-formed rate limits which are not presets, since the device may report
+// them, and they must be written back unchanged when editing other settings.
+func (q WlanQos) validate() error {
+	for _, limit := range []struct {
+		direction string
+		value     RateLimit
+	}{{"uplink", q.UplinkPreset}, {"downlink", q.DownlinkPreset}} {
+		if _, ok := limit.value.Kbps(); !ok {
+			return fmt.Errorf("%s: invalid rate limit %q", limit.direction, string(limit.value))
+		}
+	}
+	if q.PerssidUplinkPreset < 0 || q.PerssidUplinkPreset > MaxPerSsidRateLimitMbps {
+		return fmt.Errorf("per-SSID uplink must be between 0 and %d Mbps", MaxPerSsidRateLimitMbps)
+	}
+	if q.PerssidDownlinkPreset < 0 || q.PerssidDownlinkPreset > MaxPerSsidRateLimitMbps {
+		return fmt.Errorf("per-SSID downlink must be between 0 and %d Mbps", MaxPerSsidRateLimitMbps)
+	}
+	return nil
+}
+
+// validatePresets additionally requires the per-station rate limits to be presets, for settings chosen by the caller.
+func (q WlanQos) validatePresets() error {
+	if err := q.validate(); err != nil {
+		return err
+	}
+	if err := q.UplinkPreset.validate(); err != nil {
+		return fmt.Errorf("uplink: %v", err)
+	}
+	if err := q.DownlinkPreset.validate(); err != nil {
+		return fmt.Errorf("downlink: %v", err)
+	}
+	return nil
+}
+
+// QoS describes how traffic is classified and prioritized across every Wlan.
+type QoS struct {
+	// Heuristics classifies voice and video traffic by packet size and rate, for traffic without other markings.
+	Heuristics EnabledBool `xml:"heuristics,attr"`
+	// TosClassification classifies traffic by the ToS/DSCP field of its IP header.
+	TosClassification EnabledBool `xml:"tos-classification,attr"`
+	// DirectedMulticast converts multicast traffic to unicast to each interested station.
+	DirectedMulticast EnabledBool `xml:"directed-multicast,attr"`
+	// DirectedThreshold is the number of stations beyond which multicast is no longer converted to unicast.
+	DirectedThreshold int `xml:"directed-threshold,attr"`
+}
+
+func (s System) GetQoS(ctx context.Context) (*QoS, error) {
+	var req struct {
+		XMLName xml.Name `xml:"qos"`
+	}
+	var resp struct {
+		XMLName xml.Name `xml:"resultset"`
+		QoS     QoS      `xml:"qos"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.QoS, nil
+	}
+}
+
+func (s System) SetQoS(ctx context.Context, settings QoS) error {
+	if settings.DirectedThreshold < 0 {
+		return errors.New("invalid QoS: directed threshold must not be negative")
+	}
+
+	req := struct {
+		XMLName xml.Name `xml:"qos"`
+		QoS
+	}{QoS: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
@@ -0,0 +1,104 @@
+package ruckusweb
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	tests := []struct {
+		text   string
+		kbps   int
+		ok     bool
+		string string
+	}{
+		{"DISABLE", 0, true, "unlimited"},
+		{"", 0, true, "unlimited"},
+		{"100kbps", 100, true, "100 kbps"},
+		{"1mbps", 1000, true, "1 Mbps"},
+		{"50mbps", 50000, true, "50 Mbps"},
+		{"0.5mbps", 500, true, "0.5 Mbps"},
+		{"10Mbps", 10000, true, "10 Mbps"},
+		{"1.5 Gbps", 0, false, "1.5 Gbps"},
+		{"-1kbps", 0, false, "-1kbps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var qos WlanQos
+			in := `<qos uplink-preset="` + tt.text + `" downlink-preset="DISABLE" perssid-uplink-preset="0" perssid-downlink-preset="0"></qos>`
+			require.NoError(t, xml.Unmarshal([]byte(in), &qos))
+			assert.Equal(t, RateLimit(tt.text), qos.UplinkPreset)
+
+			kbps, ok := qos.UplinkPreset.Kbps()
+			assert.Equal(t, tt.kbps, kbps)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.string, qos.UplinkPreset.String())
+
+			// Values are written back exactly as read
+			out, err := xml.Marshal(struct {
+				XMLName xml.Name `xml:"qos"`
+				WlanQos
+			}{WlanQos: qos})
+			require.NoError(t, err)
+			assert.Equal(t, in, string(out))
+		})
+	}
+}
+
+func TestWlanQosValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(q *WlanQos)
+		// want is the error from Wlan.validate, which accepts well-formed rate limits read from the device
+		want string
+		// wantPresets is the error from validatePresets, which applies to rate limits chosen by the caller
+		wantPresets string
+	}{
+		{"defaults", func(q *WlanQos) {}, "", ""},
+		{"empty", func(q *WlanQos) { *q = WlanQos{} }, "", ""},
+		{"presets", func(q *WlanQos) {
+			q.UplinkPreset = RateLimit5Mbps
+			q.PerssidDownlinkPreset = 100
+		}, "", ""},
+		{"not a preset", func(q *WlanQos) { q.DownlinkPreset = "3mbps" },
+			"",
+			`downlink: rate limit "3mbps" is not one of the presets`},
+		{"fractional", func(q *WlanQos) { q.UplinkPreset = "0.5mbps" },
+			"",
+			`uplink: rate limit "0.5mbps" is not one of the presets`},
+		{"garbage", func(q *WlanQos) { q.UplinkPreset = "fast" },
+			`WLAN QoS: uplink: invalid rate limit "fast"`,
+			`uplink: invalid rate limit "fast"`},
+		{"missing unit", func(q *WlanQos) { q.DownlinkPreset = "10" },
+			`WLAN QoS: downlink: invalid rate limit "10"`,
+			`downlink: invalid rate limit "10"`},
+		{"per-SSID uplink too high", func(q *WlanQos) { q.PerssidUplinkPreset = MaxPerSsidRateLimitMbps + 1 },
+			"WLAN QoS: per-SSID uplink must be between 0 and 200 Mbps",
+			"per-SSID uplink must be between 0 and 200 Mbps"},
+		{"per-SSID downlink negative", func(q *WlanQos) { q.PerssidDownlinkPreset = -1 },
+			"WLAN QoS: per-SSID downlink must be between 0 and 200 Mbps",
+			"per-SSID downlink must be between 0 and 200 Mbps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wlan := NewWlan("test")
+			wlan.Description = "test"
+			wlan.Ssid = "test"
+			tt.edit(&wlan.Qos)
+
+			if err := wlan.validate(); tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+			if err := wlan.Qos.validatePresets(); tt.wantPresets == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantPresets)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package ruckusweb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// Reboot asks the Unleashed master AP to reboot. Other APs remain up, although they will elect a new master if the
+// reboot takes too long.
+func (s System) Reboot(ctx context.Context) error {
+	type xcmd struct {
+		Cmd string `xml:"cmd,attr"`
+	}
+	return s.c.docmd(ctx, "worker", &xcmd{Cmd: "reboot"})
+}
+
+// RestartNetwork asks every AP in the network, including the master, to reboot.
+func (s System) RestartNetwork(ctx context.Context) error {
+	type xcmd struct {
+		Cmd string `xml:"cmd,attr"`
+	}
+	return s.c.docmd(ctx, "worker", &xcmd{Cmd: "reset-all-ap"})
+}
+
+// RebootAndWait reboots as per Reboot, then blocks until Sysinfo shows the device has come back up, polling every
+// interval. The returned duration is how long the device was unreachable, measured between polls, so it is accurate
+// to within interval.
+func (s System) RebootAndWait(ctx context.Context, interval time.Duration) (time.Duration, error) {
+	return s.restartAndWait(ctx, interval, s.Reboot)
+}
+
+// RestartNetworkAndWait restarts the network as per RestartNetwork, then blocks until Sysinfo shows the master has
+// come back up. The returned duration is how long the master was unreachable, as per RebootAndWait.
+func (s System) RestartNetworkAndWait(ctx context.Context, interval time.Duration) (time.Duration, error) {
+	return s.restartAndWait(ctx, interval, s.RestartNetwork)
+}
+
+func (s System) restartAndWait(ctx context.Context, interval time.Duration, restart func(context.Context) error) (time.Duration, error) {
+	return waitForRestart(ctx, interval, s.c.Sysinfo, restart, s.c.resetLogin)
+}
+
+// waitForRestart issues restart and polls sysinfo until the device has restarted, returning the length of the outage.
+// resetLogin is called after each failed poll.
+func waitForRestart(ctx context.Context, interval time.Duration, sysinfo func(context.Context) (*Sysinfo, error), restart func(context.Context) error, resetLogin func()) (time.Duration, error) {
+	// Check before restarting, not after, so a bad interval doesn't leave the device rebooting unwatched
+	if interval <= 0 {
+		return 0, errors.New("poll interval must be positive")
+	}
+
+	before, err := sysinfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := restart(ctx); err != nil {
+		// The device often drops the connection while acting on the command, so a transport error may mean the restart
+		// was issued. Anything else, like an error response, means it wasn't.
+		if ctx.Err() != nil || !isTransportError(err) {
+			return 0, err
+		}
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	// lastUp is the last time the device answered before restarting, and downSince the first poll it didn't answer
+	lastUp := time.Now()
+	var downSince time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-t.C:
+		}
+
+		info, err := sysinfo(ctx)
+		if err != nil {
+			// Still down; the old session won't survive the reboot, so start a new one next time
+			if downSince.IsZero() {
+				downSince = time.Now()
+			}
+			resetLogin()
+			continue
+		}
+
+		if !downSince.IsZero() {
+			return time.Since(downSince), nil
+		}
+		// Uptime going backwards catches reboots that happen entirely between polls
+		if info.Uptime < before.Uptime {
+			return time.Since(lastUp), nil
+		}
+		lastUp = time.Now()
+	}
+}
+
+// isTransportError returns true if err came from the connection rather than from the device's response.
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
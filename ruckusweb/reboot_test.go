@@ -0,0 +1,95 @@
+package ruckusweb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForRestart(t *testing.T) {
+	down := errors.New("connection refused")
+	dropped := &url.Error{Op: "Post", URL: "https://unleashed/admin/_cmdstat.jsp", Err: io.EOF}
+
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		restartErr error
+		// polls are the results of successive Sysinfo calls after the initial one: an uptime, or -1 for an error
+		polls       []int
+		wantErr     string
+		wantRestart bool
+		wantResets  int
+	}{
+		{
+			name:        "down then up",
+			interval:    time.Millisecond,
+			polls:       []int{100, -1, -1, 5},
+			wantRestart: true,
+			wantResets:  2,
+		},
+		{
+			name:        "restart between polls",
+			interval:    time.Millisecond,
+			polls:       []int{100, 3},
+			wantRestart: true,
+		},
+		{
+			name:        "connection dropped while restarting",
+			interval:    time.Millisecond,
+			restartErr:  dropped,
+			polls:       []int{-1, 5},
+			wantRestart: true,
+			wantResets:  1,
+		},
+		{
+			name:        "restart refused",
+			interval:    time.Millisecond,
+			restartErr:  errors.New("permission denied"),
+			wantErr:     "permission denied",
+			wantRestart: true,
+		},
+		{
+			name:     "bad interval",
+			interval: 0,
+			wantErr:  "poll interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			sysinfo := func(ctx context.Context) (*Sysinfo, error) {
+				calls++
+				if calls == 1 {
+					return &Sysinfo{Uptime: 90}, nil
+				}
+				uptime := tt.polls[calls-2]
+				if uptime < 0 {
+					return nil, down
+				}
+				return &Sysinfo{Uptime: uptime}, nil
+			}
+			restarted := false
+			restart := func(ctx context.Context) error {
+				restarted = true
+				return tt.restartErr
+			}
+			resets := 0
+
+			outage, err := waitForRestart(context.Background(), tt.interval, sysinfo, restart, func() { resets++ })
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Greater(t, outage, time.Duration(0))
+				assert.Equal(t, len(tt.polls)+1, calls)
+			}
+			assert.Equal(t, tt.wantRestart, restarted)
+			assert.Equal(t, tt.wantResets, resets)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Roles manages user roles. A role grants a user access to Wlans, and optionally the right to generate guest passes or
+// administer the network. Users authenticated by an AAA server are assigned the role whose GroupAttr matches the
+// server's group attribute (AaaRadius.GroupString or AaaActiveDirectory.GroupString) for that user.
+type Roles struct {
+	c *Client
+}
+
+func (c *Client) Roles() Roles {
+	return Roles{c}
+}
+
+type Role struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	// GroupAttr is matched against the user's group attribute, e.g. an Active Directory group's distinguished name.
+	GroupAttr string `xml:"group-attr,attr"`
+
+	// AllWlans grants access to every Wlan with Wlan.RoleBasedAccessCtrl set. Otherwise access is limited to Wlans.
+	AllWlans bool       `xml:"allow-all-wlansvc,attr"`
+	Wlans    []RoleWlan `xml:"wlansvc"`
+
+	// GuestPass allows members to generate guest passes.
+	GuestPass bool `xml:"guest-pass,attr"`
+	// AllowAdmin allows members to log in to the web interface with AdminPrivilege.
+	AllowAdmin     bool           `xml:"allow-admin,attr"`
+	AdminPrivilege AdminPrivilege `xml:"admin-privilege,attr,omitempty"`
+}
+
+// RoleWlan refers to a Wlan by ID.
+type RoleWlan struct {
+	ID int `xml:"id,attr"`
+}
+
+func (r Role) validate() error {
+	if len(r.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if r.AllWlans && len(r.Wlans) > 0 {
+		return errors.New("Wlans must be empty when AllWlans is set")
+	}
+	if r.AllowAdmin && r.AdminPrivilege == AdminPrivilegeUnknown {
+		return errors.New("AdminPrivilege must be set when AllowAdmin is set")
+	}
+	if !r.AllowAdmin && r.AdminPrivilege != AdminPrivilegeUnknown {
+		return errors.New("AdminPrivilege requires AllowAdmin")
+	}
+	return nil
+}
+
+func (r Roles) List(ctx context.Context) ([]Role, error) {
+	var resp struct {
+		XMLName xml.Name `xml:"role-list"`
+		Role    []Role   `xml:"role"`
+	}
+
+	if err := r.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "role-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Role, nil
+	}
+}
+
+// Create creates a Role, returning the created record.
+func (r Roles) Create(ctx context.Context, role Role) (*Role, error) {
+	var req struct {
+		XMLName xml.Name `xml:"role"`
+		Role
+	}
+	req.Role = role
+	req.Role.ID = 0 // ensure we don't specify one
+
+	if err := req.Role.validate(); err != nil {
+		return nil, fmt.Errorf("invalid Role: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"role"`
+		Role
+	}
+
+	if err := r.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "role-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Role, nil
+	}
+}
+
+// Update updates a Role, replacing the record.
+func (r Roles) Update(ctx context.Context, role Role) error {
+	req := struct {
+		XMLName xml.Name `xml:"role"`
+		Role
+	}{
+		Role: role,
+	}
+
+	if err := req.Role.validate(); err != nil {
+		return fmt.Errorf("invalid Role: %v", err)
+	}
+
+	return r.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "role-list",
+	}, &req, nil)
+}
+
+// Delete a Role by ID. Users with the role revert to the Default role.
+func (r Roles) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"role"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return r.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "role-list",
+	}, &req, nil)
+}
+
+// StationRole is a connected station and the role it was assigned.
+type StationRole struct {
+	Station Station
+	// Role is nil if Station.RoleID does not refer to a known role, e.g. because the station authenticated without one.
+	Role *Role
+}
+
+// Stations returns every connected station along with its role.
+func (r Roles) Stations(ctx context.Context) ([]StationRole, error) {
+	roles, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stations, err := r.c.Stations().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*Role, len(roles))
+	for i := range roles {
+		byID[roles[i].ID] = &roles[i]
+	}
+
+	out := make([]StationRole, len(stations))
+	for i, station := range stations {
+		out[i] = StationRole{Station: station, Role: byID[station.RoleID]}
+	}
+	return out, nil
+}
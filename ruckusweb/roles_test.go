@@ -0,0 +1,28 @@
+package ruckusweb
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleEncoding(t *testing.T) {
+	// Most roles have no admin rights, and so no admin-privilege attribute
+	sr := StationRole{Role: &Role{ID: 1, Name: "Default"}}
+	_, err := json.Marshal(sr)
+	assert.NoError(t, err)
+
+	b, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"role"`
+		Role
+	}{Role: *sr.Role})
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "admin-privilege")
+
+	var decoded Role
+	require.NoError(t, xml.Unmarshal(b, &decoded))
+	assert.Equal(t, *sr.Role, decoded)
+}
@@ -0,0 +1,362 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotVersion is the version of the Snapshot format produced by this package.
+const SnapshotVersion = 1
+
+type SnapshotOptions struct {
+	// IncludeSecrets retains passphrases, shared secrets, SNMP communities and similar values. Otherwise they are
+	// cleared, and Diff cannot detect changes to them.
+	IncludeSecrets bool
+}
+
+// Snapshot is a point-in-time copy of a network's configuration, suitable for storing as JSON.
+type Snapshot struct {
+	Version int       `json:"version"`
+	Host    string    `json:"host"`
+	TakenAt time.Time `json:"taken_at"`
+	// IncludesSecrets is true if the snapshot was taken with SnapshotOptions.IncludeSecrets.
+	IncludesSecrets bool `json:"includes_secrets"`
+
+	Wlans              []Wlan               `json:"wlans"`
+	APGroups           []APGroup            `json:"ap_groups"`
+	APs                []AP                 `json:"aps"`
+	AaaRadius          []AaaRadius          `json:"aaa_radius"`
+	AaaActiveDirectory []AaaActiveDirectory `json:"aaa_active_directory"`
+	Admins             []AdminAccount       `json:"admins"`
+
+	SNMPv2   *SNMPv2   `json:"snmp_v2"`
+	SNMPv3   *SNMPv3   `json:"snmp_v3"`
+	SNMPTrap *SNMPTrap `json:"snmp_trap"`
+
+	System SnapshotSystem `json:"system"`
+}
+
+// SnapshotSystem holds the system-wide settings in a Snapshot.
+type SnapshotSystem struct {
+	Identity         *SystemIdentity   `json:"identity"`
+	Network          *SystemNetwork    `json:"network"`
+	Time             *SystemTime       `json:"time"`
+	Log              *LogSettings      `json:"log"`
+	Mesh             *MeshPolicy       `json:"mesh"`
+	AwsSns           *AwsSns           `json:"aws_sns"`
+	Pubnub           *Pubnub           `json:"pubnub"`
+	ZeroIt           *ZeroIt           `json:"zero_it"`
+	UnleashedNetwork *UnleashedNetwork `json:"unleashed_network"`
+	Admin            *AdminSettings    `json:"admin"`
+}
+
+// Snapshot reads every part of the network's configuration which this package supports.
+func (c *Client) Snapshot(ctx context.Context, opts SnapshotOptions) (*Snapshot, error) {
+	s := &Snapshot{
+		Version:         SnapshotVersion,
+		Host:            c.Host(),
+		TakenAt:         time.Now().UTC(),
+		IncludesSecrets: opts.IncludeSecrets,
+	}
+
+	var err error
+	if s.Wlans, err = c.Wlans().List(ctx); err != nil {
+		return nil, err
+	}
+	if s.APGroups, err = c.APGroups().List(ctx); err != nil {
+		return nil, err
+	}
+	if s.APs, err = c.APs().List(ctx); err != nil {
+		return nil, err
+	}
+	if servers, err := c.AAA().List(ctx); err != nil {
+		return nil, err
+	} else {
+		for _, server := range servers {
+			switch server := server.(type) {
+			case *AaaRadius:
+				s.AaaRadius = append(s.AaaRadius, *server)
+			case *AaaActiveDirectory:
+				s.AaaActiveDirectory = append(s.AaaActiveDirectory, *server)
+			}
+		}
+	}
+	if s.Admins, err = c.Admins().List(ctx); err != nil {
+		return nil, err
+	}
+
+	if s.SNMPv2, err = c.SNMP().GetV2(ctx); err != nil {
+		return nil, err
+	}
+	if s.SNMPv3, err = c.SNMP().GetV3(ctx); err != nil {
+		return nil, err
+	}
+	if s.SNMPTrap, err = c.SNMP().GetTrap(ctx); err != nil {
+		return nil, err
+	}
+
+	system := c.System()
+	if s.System.Identity, err = system.GetIdentity(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Network, err = system.GetNetwork(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Time, err = system.GetTime(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Log, err = system.GetLogSettings(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Mesh, err = system.GetMeshPolicy(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.AwsSns, err = system.GetAwsSns(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Pubnub, err = system.GetPubnub(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.ZeroIt, err = system.GetZeroIt(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.UnleashedNetwork, err = system.GetUnleashedNetwork(ctx); err != nil {
+		return nil, err
+	}
+	if s.System.Admin, err = c.Admins().GetSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	if !opts.IncludeSecrets {
+		s.redact()
+	}
+	return s, nil
+}
+
+// redact clears every secret in the snapshot.
+func (s *Snapshot) redact() {
+	for i := range s.Wlans {
+		if wpa := s.Wlans[i].Wpa; wpa != nil {
+			wpa.Passphrase, wpa.XPassphrase = "", ""
+			wpa.SaePassphrase, wpa.XSaePassphrase = "", ""
+		}
+	}
+	for i := range s.AaaRadius {
+		for _, endpoint := range []*AaaRadiusEndpoint{s.AaaRadius[i].PrimaryRadius, s.AaaRadius[i].SecondaryRadius} {
+			if endpoint != nil {
+				endpoint.Secret, endpoint.XSecret = "", ""
+			}
+		}
+	}
+	for i := range s.AaaActiveDirectory {
+		s.AaaActiveDirectory[i].AdminPwd, s.AaaActiveDirectory[i].XAdminPwd = "", ""
+	}
+	for i := range s.Admins {
+		s.Admins[i].XPassword = ""
+	}
+
+	if s.SNMPv2 != nil {
+		s.SNMPv2.RoCommunity, s.SNMPv2.RwCommunity = "", ""
+	}
+	if s.SNMPv3 != nil {
+		for i := range s.SNMPv3.Snmpusr {
+			s.SNMPv3.Snmpusr[i].AuthPP, s.SNMPv3.Snmpusr[i].PrivPP = "", ""
+		}
+	}
+	if s.SNMPTrap != nil {
+		s.SNMPTrap.Community, s.SNMPTrap.Password = "", ""
+		for i := range s.SNMPTrap.TrapV3s {
+			s.SNMPTrap.TrapV3s[i].AuthPP, s.SNMPTrap.TrapV3s[i].PrivPP = "", ""
+		}
+	}
+
+	if s.System.AwsSns != nil {
+		s.System.AwsSns.AwsSnsSecretkey = ""
+	}
+	if s.System.UnleashedNetwork != nil {
+		s.System.UnleashedNetwork.UnleashedNetworkToken = ""
+	}
+	if s.System.Admin != nil {
+		s.System.Admin.XPassword = ""
+	}
+}
+
+// Difference is a single field which differs between two snapshots.
+type Difference struct {
+	// Path identifies the field, e.g. `wlans["Guest"].VlanID` or `aps["00:11:22:33:44:55"].Location`. Objects in
+	// lists are identified by name, or by MAC address for APs, so that reordering or renumbering is not reported.
+	Path string
+	// Old and New are the field's values as decoded from JSON, or nil if the field or object is absent.
+	Old, New any
+}
+
+func (d Difference) String() string {
+	switch {
+	case d.Old == nil:
+		return fmt.Sprintf("+ %s: %s", d.Path, formatDiffValue(d.New))
+	case d.New == nil:
+		return fmt.Sprintf("- %s: %s", d.Path, formatDiffValue(d.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, formatDiffValue(d.Old), formatDiffValue(d.New))
+	}
+}
+
+func formatDiffValue(v any) string {
+	if b, err := json.Marshal(v); err != nil {
+		return fmt.Sprint(v)
+	} else {
+		return string(b)
+	}
+}
+
+// volatileFields lists fields which change without anyone reconfiguring the network, and which Diff ignores.
+var volatileFields = map[string][]string{
+	"aps":           {"LastSeen", "ConfigState", "ExtIp", "ExtIpv6", "ExtPort", "ExtFamily", "UdpPort"},
+	"system.time":   {"Time", "IsDaylightSavingTime"},
+	"system.pubnub": {"State", "StatusCode"},
+}
+
+// Diff reports every configuration difference between two snapshots, ignoring fields such as an AP's LastSeen which
+// change during normal operation. Differences are sorted by Path. Diff returns an error if either snapshot contains a
+// value which cannot be encoded, e.g. an out-of-range enum.
+func Diff(a, b *Snapshot) ([]Difference, error) {
+	var d snapshotDiffer
+	diffKeyed(&d, "wlans", a.Wlans, b.Wlans, func(w Wlan) string { return w.Name })
+	diffKeyed(&d, "ap_groups", a.APGroups, b.APGroups, func(g APGroup) string { return g.Name })
+	diffKeyed(&d, "aps", a.APs, b.APs, func(ap AP) string { return net.HardwareAddr(ap.Mac).String() })
+	diffKeyed(&d, "aaa_radius", a.AaaRadius, b.AaaRadius, func(s AaaRadius) string { return s.Name() })
+	diffKeyed(&d, "aaa_active_directory", a.AaaActiveDirectory, b.AaaActiveDirectory, func(s AaaActiveDirectory) string { return s.Name() })
+	diffKeyed(&d, "admins", a.Admins, b.Admins, func(admin AdminAccount) string { return admin.Name })
+
+	d.value("snmp_v2", "snmp_v2", a.SNMPv2, b.SNMPv2)
+	d.value("snmp_v3", "snmp_v3", a.SNMPv3, b.SNMPv3)
+	d.value("snmp_trap", "snmp_trap", a.SNMPTrap, b.SNMPTrap)
+
+	av, bv := reflect.ValueOf(a.System), reflect.ValueOf(b.System)
+	for i := 0; i < av.NumField(); i++ {
+		name := "system." + strings.Split(av.Type().Field(i).Tag.Get("json"), ",")[0]
+		d.value(name, name, av.Field(i).Interface(), bv.Field(i).Interface())
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+	sort.SliceStable(d.diffs, func(i, j int) bool { return d.diffs[i].Path < d.diffs[j].Path })
+	return d.diffs, nil
+}
+
+// snapshotDiffer accumulates differences, stopping at the first value which cannot be encoded.
+type snapshotDiffer struct {
+	diffs []Difference
+	err   error
+}
+
+// diffKeyed compares two lists of objects by key.
+func diffKeyed[T any](d *snapshotDiffer, collection string, a, b []T, key func(T) string) {
+	byKey := make(map[string]T, len(a))
+	for _, v := range a {
+		byKey[key(v)] = v
+	}
+	seen := make(map[string]bool, len(b))
+	for _, v := range b {
+		k := key(v)
+		seen[k] = true
+		path := fmt.Sprintf("%s[%q]", collection, k)
+		if old, ok := byKey[k]; ok {
+			d.value(collection, path, old, v)
+		} else {
+			d.value(collection, path, nil, v)
+		}
+	}
+	for k, v := range byKey {
+		if !seen[k] {
+			d.value(collection, fmt.Sprintf("%s[%q]", collection, k), v, nil)
+		}
+	}
+}
+
+// value compares two values by their JSON representations, omitting the volatile fields of kind.
+func (d *snapshotDiffer) value(kind, path string, a, b any) {
+	if d.err != nil {
+		return
+	}
+	at, err := snapshotTree(a)
+	if err != nil {
+		d.err = fmt.Errorf("%s: %w", path, err)
+		return
+	}
+	bt, err := snapshotTree(b)
+	if err != nil {
+		d.err = fmt.Errorf("%s: %w", path, err)
+		return
+	}
+	for _, field := range volatileFields[kind] {
+		if m, ok := at.(map[string]any); ok {
+			delete(m, field)
+		}
+		if m, ok := bt.(map[string]any); ok {
+			delete(m, field)
+		}
+	}
+	d.tree(path, at, bt)
+}
+
+func (d *snapshotDiffer) tree(path string, a, b any) {
+	am, aIsMap := a.(map[string]any)
+	bm, bIsMap := b.(map[string]any)
+	if aIsMap && bIsMap {
+		keys := make(map[string]bool, len(am)+len(bm))
+		for k := range am {
+			keys[k] = true
+		}
+		for k := range bm {
+			keys[k] = true
+		}
+		for k := range keys {
+			d.tree(path+"."+k, am[k], bm[k])
+		}
+		return
+	}
+
+	as, aIsSlice := a.([]any)
+	bs, bIsSlice := b.([]any)
+	if aIsSlice && bIsSlice {
+		for i := 0; i < len(as) || i < len(bs); i++ {
+			var av, bv any
+			if i < len(as) {
+				av = as[i]
+			}
+			if i < len(bs) {
+				bv = bs[i]
+			}
+			d.tree(fmt.Sprintf("%s[%d]", path, i), av, bv)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		d.diffs = append(d.diffs, Difference{Path: path, Old: a, New: b})
+	}
+}
+
+// snapshotTree converts v to its generic JSON representation.
+func snapshotTree(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
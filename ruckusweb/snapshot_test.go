@@ -0,0 +1,155 @@
+package ruckusweb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func differenceStrings(diffs []Difference) []string {
+	var out []string
+	for _, d := range diffs {
+		out = append(out, d.String())
+	}
+	return out
+}
+
+func TestDiff(t *testing.T) {
+	ap1 := mustMac("00:00:00:00:01:01")
+	base := func() *Snapshot {
+		return &Snapshot{
+			Version: SnapshotVersion,
+			Wlans: []Wlan{
+				{ID: 1, Name: "corp", Ssid: "corp", VlanID: 1},
+				{ID: 2, Name: "guest", Ssid: "guest", VlanID: 1},
+			},
+			APs: []AP{
+				{ID: 1, Mac: ap1, Devname: "ap1", LastSeen: Timestamp{time.Unix(1000, 0)}},
+			},
+			Admins: []AdminAccount{
+				{ID: 1, Name: "ops", Privilege: AdminPrivilegeOperator},
+				{ID: 2, Name: "legacy"},
+			},
+			System: SnapshotSystem{
+				Time:  &SystemTime{Timezone: "UTC", Time: "1000"},
+				Admin: &AdminSettings{Username: "admin", AuthBy: "local"},
+			},
+		}
+	}
+	removedGuest, err := snapshotTree(base().Wlans[1])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(s *Snapshot)
+		want   []string
+		err    string
+	}{
+		{
+			"identical",
+			func(s *Snapshot) {},
+			nil,
+			"",
+		},
+		{
+			"reordered",
+			func(s *Snapshot) {
+				s.Wlans[0], s.Wlans[1] = s.Wlans[1], s.Wlans[0]
+				s.Admins[0], s.Admins[1] = s.Admins[1], s.Admins[0]
+			},
+			nil,
+			"",
+		},
+		{
+			"volatile",
+			func(s *Snapshot) {
+				s.APs[0].LastSeen = Timestamp{time.Unix(2000, 0)}
+				s.System.Time.Time = "2000"
+			},
+			nil,
+			"",
+		},
+		{
+			"changed",
+			func(s *Snapshot) {
+				s.Wlans[1].VlanID = 20
+				s.APs[0].Location = "lobby"
+				s.System.Time.Timezone = "PST8PDT"
+				s.Admins[1].Privilege = AdminPrivilegeMonitor
+				s.System.Admin.AuthBy = "external"
+			},
+			[]string{
+				`~ admins["legacy"].Privilege: "" -> "monitor"`,
+				`~ aps["00:00:00:00:01:01"].Location: "" -> "lobby"`,
+				`~ system.admin.AuthBy: "local" -> "external"`,
+				`~ system.time.Timezone: "UTC" -> "PST8PDT"`,
+				`~ wlans["guest"].VlanID: 1 -> 20`,
+			},
+			"",
+		},
+		{
+			"added and removed",
+			func(s *Snapshot) {
+				s.Wlans = s.Wlans[:1]
+				s.SNMPv2 = &SNMPv2{}
+			},
+			[]string{
+				`+ snmp_v2: {"Enabled":false,"RoCommunity":"","RwCommunity":"","Snmpv2Ap":false,"SysContact":"","SysLocation":"","Ver":0}`,
+				`- wlans["guest"]: ` + formatDiffValue(removedGuest),
+			},
+			"",
+		},
+		{
+			"unencodable",
+			func(s *Snapshot) {
+				s.Admins[0].Privilege = AdminPrivilege(9)
+			},
+			nil,
+			`admins["ops"]: json: error calling MarshalText for type *ruckusweb.AdminPrivilege: invalid AdminPrivilege: 9`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := base()
+			tt.modify(modified)
+			diffs, err := Diff(base(), modified)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, differenceStrings(diffs))
+		})
+	}
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	s := &Snapshot{
+		Version: SnapshotVersion,
+		Wlans:   []Wlan{{ID: 1, Name: "corp", Encryption: WlanEncryptionWpa3, Wpa: &WlanWpa{SaePassphrase: "password1"}}},
+		APs:     []AP{{ID: 1, Mac: mustMac("00:00:00:00:01:01"), LastSeen: Timestamp{time.Unix(1000, 0)}}},
+		Admins: []AdminAccount{
+			{ID: 1, Name: "ops", Privilege: AdminPrivilegeOperator},
+			{ID: 2, Name: "legacy"}, // no privilege attribute
+		},
+		System: SnapshotSystem{
+			Admin: &AdminSettings{Username: "admin", XPassword: "secret", AuthBy: "local"},
+		},
+	}
+	s.Wlans[0].WlanSchedule[1][2] = true
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	var decoded Snapshot
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	diffs, err := Diff(s, &decoded)
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	s.redact()
+	assert.Equal(t, "", s.Wlans[0].Wpa.SaePassphrase)
+	assert.Equal(t, "", s.System.Admin.XPassword)
+}
@@ -179,28 +179,12 @@ func (s Stations) SetFavorite(ctx context.Context, client MacAddress, favorite b
 		Client MacAddress `xml:"client,attr"`
 	}
 
-	req := struct {
-		XMLName  xml.Name `xml:"ajax-request"`
-		Action   string   `xml:"action,attr"`
-		AttrXcmd string   `xml:"xcmd,attr"`
-		Updater  string   `xml:"updater,attr"`
-		Comp     string   `xml:"comp,attr"`
-		Xcmd     xcmd     `xml:"xcmd"`
-	}{
-		Action:   "docmd",
-		AttrXcmd: "stamgr",
-		Comp:     "stamgr",
-		Xcmd: xcmd{
-			Cmd:    "favourite",
-			Tag:    "client",
-			Enable: IntBool(favorite),
-			Client: client,
-		},
-	}
-	var resp struct {
-		XMLName xml.Name `xml:"ajax-request"`
-	}
-	return s.c.cmdstat(ctx, &req, &resp)
+	return s.c.docmd(ctx, "stamgr", &xcmd{
+		Cmd:    "favourite",
+		Tag:    "client",
+		Enable: IntBool(favorite),
+		Client: client,
+	})
 }
 
 func (s Stations) SetLegacy(ctx context.Context, client MacAddress, legacy bool) error {
@@ -211,28 +195,12 @@ func (s Stations) SetLegacy(ctx context.Context, client MacAddress, legacy bool)
 		Client MacAddress `xml:"client,attr"`
 	}
 
-	req := struct {
-		XMLName  xml.Name `xml:"ajax-request"`
-		Action   string   `xml:"action,attr"`
-		AttrXcmd string   `xml:"xcmd,attr"`
-		Updater  string   `xml:"updater,attr"`
-		Comp     string   `xml:"comp,attr"`
-		Xcmd     xcmd     `xml:"xcmd"`
-	}{
-		Action:   "docmd",
-		AttrXcmd: "stamgr",
-		Comp:     "stamgr",
-		Xcmd: xcmd{
-			Cmd:    "mark-iot",
-			Tag:    "client",
-			Enable: IntBool(legacy),
-			Client: client,
-		},
-	}
-	var resp struct {
-		XMLName xml.Name `xml:"ajax-request"`
-	}
-	return s.c.cmdstat(ctx, &req, &resp)
+	return s.c.docmd(ctx, "stamgr", &xcmd{
+		Cmd:    "mark-iot",
+		Tag:    "client",
+		Enable: IntBool(legacy),
+		Client: client,
+	})
 }
 
 // SetName sets the name of a client. If the name is non-empty, the given name will override the automatic name, and the
@@ -247,26 +215,10 @@ func (s Stations) SetName(ctx context.Context, client MacAddress, name string) e
 		Rename string     `xml:"rename,attr"`
 	}
 
-	req := struct {
-		XMLName  xml.Name `xml:"ajax-request"`
-		Action   string   `xml:"action,attr"`
-		AttrXcmd string   `xml:"xcmd,attr"`
-		Updater  string   `xml:"updater,attr"`
-		Comp     string   `xml:"comp,attr"`
-		Xcmd     xcmd     `xml:"xcmd"`
-	}{
-		Action:   "docmd",
-		AttrXcmd: "stamgr",
-		Comp:     "stamgr",
-		Xcmd: xcmd{
-			Cmd:    "rename",
-			Tag:    "client",
-			Client: client,
-			Rename: name,
-		},
-	}
-	var resp struct {
-		XMLName xml.Name `xml:"ajax-request"`
-	}
-	return s.c.cmdstat(ctx, &req, &resp)
+	return s.c.docmd(ctx, "stamgr", &xcmd{
+		Cmd:    "rename",
+		Tag:    "client",
+		Client: client,
+		Rename: name,
+	})
 }
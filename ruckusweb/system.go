@@ -0,0 +1,325 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+type System struct {
+	c *Client
+}
+
+func (c *Client) System() System {
+	return System{c}
+}
+
+// SystemTime describes how the device keeps time.
+type SystemTime struct {
+	ByNtp                     bool   `xml:"by-ntp,attr"`
+	Ntp1                      string `xml:"ntp1,attr"`
+	Timezone                  string `xml:"timezone,attr"`
+	DaylightSaving            bool   `xml:"daylight-saving,attr"`
+	UserDefined               bool   `xml:"user-defined,attr"`
+	UserDefinedDaylightSaving bool   `xml:"user-defined-daylight-saving,attr"`
+	UserDefinedGmtOffset      string `xml:"user-defined-gmt-offset,attr"`
+	UserDefinedDstTime        string `xml:"user-defined-dst-time,attr"`
+	TzString                  string `xml:"tzString,attr"`
+
+	// Time and IsDaylightSavingTime report the device's clock, and are ignored by SetTime.
+	Time                 string `xml:"time,attr"`
+	IsDaylightSavingTime bool   `xml:"is-daylight-saving-time,attr"`
+}
+
+func (s System) GetTime(ctx context.Context) (*SystemTime, error) {
+	var req struct {
+		XMLName xml.Name `xml:"time"`
+	}
+	var resp struct {
+		XMLName xml.Name   `xml:"resultset"`
+		Time    SystemTime `xml:"time"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Time, nil
+	}
+}
+
+func (s System) SetTime(ctx context.Context, settings SystemTime) error {
+	// Time and IsDaylightSavingTime are read-only, so they're left out rather than writing back a stale clock
+	req := struct {
+		XMLName                   xml.Name `xml:"time"`
+		ByNtp                     bool     `xml:"by-ntp,attr"`
+		Ntp1                      string   `xml:"ntp1,attr"`
+		Timezone                  string   `xml:"timezone,attr"`
+		DaylightSaving            bool     `xml:"daylight-saving,attr"`
+		UserDefined               bool     `xml:"user-defined,attr"`
+		UserDefinedDaylightSaving bool     `xml:"user-defined-daylight-saving,attr"`
+		UserDefinedGmtOffset      string   `xml:"user-defined-gmt-offset,attr"`
+		UserDefinedDstTime        string   `xml:"user-defined-dst-time,attr"`
+		TzString                  string   `xml:"tzString,attr"`
+	}{
+		ByNtp:                     settings.ByNtp,
+		Ntp1:                      settings.Ntp1,
+		Timezone:                  settings.Timezone,
+		DaylightSaving:            settings.DaylightSaving,
+		UserDefined:               settings.UserDefined,
+		UserDefinedDaylightSaving: settings.UserDefinedDaylightSaving,
+		UserDefinedGmtOffset:      settings.UserDefinedGmtOffset,
+		UserDefinedDstTime:        settings.UserDefinedDstTime,
+		TzString:                  settings.TzString,
+	}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// LogSettings describes the device's event log and remote syslog.
+type LogSettings struct {
+	Level           string `xml:"level,attr"`
+	NumEntries      int    `xml:"num-entries,attr"`
+	EnableRemoteLog bool   `xml:"enable-remote-log,attr"`
+	RemoteLogServer string `xml:"remote-log-server,attr"`
+}
+
+func (s System) GetLogSettings(ctx context.Context) (*LogSettings, error) {
+	var req struct {
+		XMLName xml.Name `xml:"log"`
+	}
+	var resp struct {
+		XMLName xml.Name    `xml:"resultset"`
+		Log     LogSettings `xml:"log"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Log, nil
+	}
+}
+
+func (s System) SetLogSettings(ctx context.Context, settings LogSettings) error {
+	req := struct {
+		XMLName xml.Name `xml:"log"`
+		LogSettings
+	}{LogSettings: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// MeshPolicy describes how APs may form a wireless mesh.
+type MeshPolicy struct {
+	Enabled           bool `xml:"enabled,attr"`
+	MaxHops           int  `xml:"max-hops,attr"`
+	MaxFanout         int  `xml:"max-fanout,attr"`
+	DetectHops        bool `xml:"detect-hops,attr"`
+	HopsWarnThreshold int  `xml:"hops-warn-threshold,attr"`
+	DetectFanout      bool `xml:"detect-fanout,attr"`
+	FanOutThreshold   int  `xml:"fan-out-threshold,attr"`
+	LoopAvoidance     bool `xml:"loop-avoidance,attr"`
+	GwTimeout         int  `xml:"gw-timeout,attr"`
+}
+
+func (s System) GetMeshPolicy(ctx context.Context) (*MeshPolicy, error) {
+	var req struct {
+		XMLName xml.Name `xml:"mesh-policy"`
+	}
+	var resp struct {
+		XMLName    xml.Name   `xml:"resultset"`
+		MeshPolicy MeshPolicy `xml:"mesh-policy"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.MeshPolicy, nil
+	}
+}
+
+func (s System) SetMeshPolicy(ctx context.Context, settings MeshPolicy) error {
+	req := struct {
+		XMLName xml.Name `xml:"mesh-policy"`
+		MeshPolicy
+	}{MeshPolicy: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// AwsSns describes event notification via Amazon SNS.
+type AwsSns struct {
+	Enabled         bool   `xml:"enabled,attr"`
+	AwsTopicarn     string `xml:"aws-topicarn,attr"`
+	AwsSnsAccesskey string `xml:"aws-sns-accesskey,attr"`
+	AwsSnsSecretkey string `xml:"aws-sns-secretkey,attr"`
+	AwsRegion       string `xml:"aws-region,attr"`
+}
+
+func (s System) GetAwsSns(ctx context.Context) (*AwsSns, error) {
+	var req struct {
+		XMLName xml.Name `xml:"aws-sns"`
+	}
+	var resp struct {
+		XMLName xml.Name `xml:"resultset"`
+		AwsSns  AwsSns   `xml:"aws-sns"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.AwsSns, nil
+	}
+}
+
+func (s System) SetAwsSns(ctx context.Context, settings AwsSns) error {
+	req := struct {
+		XMLName xml.Name `xml:"aws-sns"`
+		AwsSns
+	}{AwsSns: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// Pubnub describes event notification via PubNub.
+type Pubnub struct {
+	Enabled      bool   `xml:"enabled,attr"`
+	PublishKey   string `xml:"publish-key,attr"`
+	SubscribeKey string `xml:"subscribe-key,attr"`
+
+	// State and StatusCode report the connection status, and are ignored by SetPubnub.
+	State      string `xml:"state,attr"`
+	StatusCode string `xml:"status_code,attr"`
+}
+
+func (s System) GetPubnub(ctx context.Context) (*Pubnub, error) {
+	var req struct {
+		XMLName xml.Name `xml:"pubnub"`
+	}
+	var resp struct {
+		XMLName xml.Name `xml:"resultset"`
+		Pubnub  Pubnub   `xml:"pubnub"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Pubnub, nil
+	}
+}
+
+func (s System) SetPubnub(ctx context.Context, settings Pubnub) error {
+	// State and StatusCode are read-only, so they're left out
+	req := struct {
+		XMLName      xml.Name `xml:"pubnub"`
+		Enabled      bool     `xml:"enabled,attr"`
+		PublishKey   string   `xml:"publish-key,attr"`
+		SubscribeKey string   `xml:"subscribe-key,attr"`
+	}{
+		Enabled:      settings.Enabled,
+		PublishKey:   settings.PublishKey,
+		SubscribeKey: settings.SubscribeKey,
+	}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// ZeroIt describes Zero-IT client activation.
+type ZeroIt struct {
+	AuthsvrID int `xml:"authsvr-id,attr"`
+}
+
+func (s System) GetZeroIt(ctx context.Context) (*ZeroIt, error) {
+	var req struct {
+		XMLName xml.Name `xml:"zero-it"`
+	}
+	var resp struct {
+		XMLName xml.Name `xml:"resultset"`
+		ZeroIt  ZeroIt   `xml:"zero-it"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.ZeroIt, nil
+	}
+}
+
+func (s System) SetZeroIt(ctx context.Context, settings ZeroIt) error {
+	req := struct {
+		XMLName xml.Name `xml:"zero-it"`
+		ZeroIt
+	}{ZeroIt: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
+
+// UnleashedNetwork describes the device's registration with the Unleashed Multi-Site Manager.
+type UnleashedNetwork struct {
+	UnleashedNetworkToken string `xml:"unleashed-network-token,attr"`
+}
+
+func (s System) GetUnleashedNetwork(ctx context.Context) (*UnleashedNetwork, error) {
+	var req struct {
+		XMLName xml.Name `xml:"unleashed-network"`
+	}
+	var resp struct {
+		XMLName          xml.Name         `xml:"resultset"`
+		UnleashedNetwork UnleashedNetwork `xml:"unleashed-network"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.UnleashedNetwork, nil
+	}
+}
+
+func (s System) SetUnleashedNetwork(ctx context.Context, settings UnleashedNetwork) error {
+	req := struct {
+		XMLName xml.Name `xml:"unleashed-network"`
+		UnleashedNetwork
+	}{UnleashedNetwork: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
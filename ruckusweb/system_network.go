@@ -0,0 +1,238 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// SystemIdentity describes how the device identifies itself.
+type SystemIdentity struct {
+	// Name is the system name, which is also the name of the Unleashed network.
+	Name string
+	// CountryCode is the two-letter regulatory domain, e.g. "US". Devices with Sysinfo.FixedCtryCode set refuse to
+	// change it.
+	CountryCode string
+}
+
+func (s System) GetIdentity(ctx context.Context) (*SystemIdentity, error) {
+	var identityReq struct {
+		XMLName xml.Name `xml:"identity"`
+	}
+	var identityResp struct {
+		XMLName  xml.Name `xml:"resultset"`
+		Identity struct {
+			Name string `xml:"name,attr"`
+		} `xml:"identity"`
+	}
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &identityReq, &identityResp); err != nil {
+		return nil, err
+	}
+
+	var countryReq struct {
+		XMLName xml.Name `xml:"country-code"`
+	}
+	var countryResp struct {
+		XMLName     xml.Name `xml:"resultset"`
+		CountryCode struct {
+			Code string `xml:"code,attr"`
+		} `xml:"country-code"`
+	}
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &countryReq, &countryResp); err != nil {
+		return nil, err
+	}
+
+	return &SystemIdentity{
+		Name:        identityResp.Identity.Name,
+		CountryCode: countryResp.CountryCode.Code,
+	}, nil
+}
+
+// SetIdentity updates the system name and country code. Changing the country code causes every AP to reboot.
+func (s System) SetIdentity(ctx context.Context, identity SystemIdentity) error {
+	if len(identity.Name) == 0 {
+		return errors.New("invalid SystemIdentity: name must be set")
+	}
+	if len(identity.CountryCode) != 2 {
+		return errors.New("invalid SystemIdentity: country code must be two letters")
+	}
+
+	current, err := s.GetIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if current.Name != identity.Name {
+		req := struct {
+			XMLName xml.Name `xml:"identity"`
+			Name    string   `xml:"name,attr"`
+		}{Name: identity.Name}
+		if err := s.c.conf(ctx, confReq{
+			Action: "setconf",
+			Comp:   "system",
+		}, &req, nil); err != nil {
+			return err
+		}
+	}
+
+	if !strings.EqualFold(current.CountryCode, identity.CountryCode) {
+		req := struct {
+			XMLName xml.Name `xml:"country-code"`
+			Code    string   `xml:"code,attr"`
+		}{Code: strings.ToUpper(identity.CountryCode)}
+		if err := s.c.conf(ctx, confReq{
+			Action: "setconf",
+			Comp:   "system",
+		}, &req, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SystemNetwork describes the device's management interface.
+type SystemNetwork struct {
+	// Ipmode is "1" for IPv4 only, "2" for IPv6 only, or "3" for dual stack.
+	Ipmode string `xml:"ipmode,attr"`
+
+	ByDhcp  bool   `xml:"by-dhcp,attr"`
+	Addr    net.IP `xml:"addr,attr"`
+	Netmask net.IP `xml:"netmask,attr"`
+	Gateway net.IP `xml:"gateway,attr"`
+	Dns1    net.IP `xml:"dns1,attr"`
+	Dns2    net.IP `xml:"dns2,attr"`
+
+	Ipv6ByAuto  bool   `xml:"ipv6-by-auto,attr"`
+	Ipv6Addr    net.IP `xml:"ipv6-addr,attr"`
+	Ipv6Plen    string `xml:"ipv6-plen,attr"`
+	Ipv6Gateway net.IP `xml:"ipv6-gateway,attr"`
+	Ipv6Dns1    net.IP `xml:"ipv6-dns1,attr"`
+	Ipv6Dns2    net.IP `xml:"ipv6-dns2,attr"`
+
+	// Vlan is the management VLAN, or 1 for untagged.
+	Vlan int `xml:"vlan,attr"`
+}
+
+func (s System) GetNetwork(ctx context.Context) (*SystemNetwork, error) {
+	var req struct {
+		XMLName xml.Name `xml:"ip"`
+	}
+	var resp struct {
+		XMLName xml.Name      `xml:"resultset"`
+		Ip      SystemNetwork `xml:"ip"`
+	}
+
+	if err := s.c.conf(ctx, confReq{
+		Action: "getconf",
+		Comp:   "system",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.Ip, nil
+	}
+}
+
+// UnreachableError is returned by SetNetwork when applying the settings would likely leave the device unreachable at
+// the Client's current host.
+type UnreachableError struct {
+	Host     string
+	Warnings []string
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("device may become unreachable at %s: %s", e.Host, strings.Join(e.Warnings, "; "))
+}
+
+// NetworkWarnings describes the ways in which applying settings could make the device unreachable at the Client's
+// current host. An empty result means no problems were detected.
+func (s System) NetworkWarnings(ctx context.Context, settings SystemNetwork) ([]string, error) {
+	current, err := s.GetNetwork(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return networkWarnings(s.c.Host(), *current, settings), nil
+}
+
+func networkWarnings(host string, current, settings SystemNetwork) []string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	hostIP := net.ParseIP(strings.Trim(host, "[]"))
+
+	// reachedAt returns true if the Client may be using addr. A hostname could resolve to any address.
+	reachedAt := func(addr net.IP) bool {
+		return hostIP == nil || hostIP.Equal(addr)
+	}
+
+	var warnings []string
+
+	if reachedAt(current.Addr) {
+		if settings.ByDhcp && !current.ByDhcp {
+			warnings = append(warnings, "switching to DHCP will change the device's IPv4 address")
+		}
+
+		if !settings.ByDhcp {
+			if len(settings.Addr) == 0 {
+				warnings = append(warnings, "no static IPv4 address is set")
+			} else if !settings.Addr.Equal(current.Addr) {
+				warnings = append(warnings, fmt.Sprintf("IPv4 address changes from %s to %s", current.Addr, settings.Addr))
+			}
+			if mask := net.IPMask(settings.Netmask.To4()); settings.Addr.To4() != nil && mask != nil && len(settings.Gateway) > 0 {
+				subnet := net.IPNet{IP: settings.Addr.Mask(mask), Mask: mask}
+				if !subnet.Contains(settings.Gateway) {
+					warnings = append(warnings, fmt.Sprintf("gateway %s is outside %s", settings.Gateway, subnet.String()))
+				}
+			}
+		}
+	}
+
+	if settings.Ipmode != current.Ipmode {
+		warnings = append(warnings, fmt.Sprintf("IP mode changes from %q to %q", current.Ipmode, settings.Ipmode))
+	}
+
+	if !settings.Ipv6ByAuto && len(current.Ipv6Addr) > 0 && !settings.Ipv6Addr.Equal(current.Ipv6Addr) && reachedAt(current.Ipv6Addr) {
+		warnings = append(warnings, fmt.Sprintf("IPv6 address changes from %s to %s", current.Ipv6Addr, settings.Ipv6Addr))
+	}
+
+	if settings.Vlan != current.Vlan {
+		warnings = append(warnings, fmt.Sprintf("management VLAN changes from %d to %d", current.Vlan, settings.Vlan))
+	}
+
+	return warnings
+}
+
+// SetNetwork updates the management interface, refusing with an *UnreachableError if NetworkWarnings reports any
+// problems. Use SetNetworkUnchecked to apply the settings regardless.
+func (s System) SetNetwork(ctx context.Context, settings SystemNetwork) error {
+	warnings, err := s.NetworkWarnings(ctx, settings)
+	if err != nil {
+		return err
+	}
+	if len(warnings) > 0 {
+		return &UnreachableError{Host: s.c.Host(), Warnings: warnings}
+	}
+	return s.SetNetworkUnchecked(ctx, settings)
+}
+
+// SetNetworkUnchecked updates the management interface without checking whether the device will remain reachable.
+func (s System) SetNetworkUnchecked(ctx context.Context, settings SystemNetwork) error {
+	req := struct {
+		XMLName xml.Name `xml:"ip"`
+		SystemNetwork
+	}{SystemNetwork: settings}
+
+	return s.c.conf(ctx, confReq{
+		Action: "setconf",
+		Comp:   "system",
+	}, &req, nil)
+}
@@ -0,0 +1,98 @@
+package ruckusweb
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkWarnings(t *testing.T) {
+	current := SystemNetwork{
+		Ipmode:   "3",
+		Addr:     net.ParseIP("192.168.1.10"),
+		Netmask:  net.ParseIP("255.255.255.0"),
+		Gateway:  net.ParseIP("192.168.1.1"),
+		Ipv6Addr: net.ParseIP("2001:db8::10"),
+		Vlan:     1,
+	}
+
+	tests := []struct {
+		name string
+		host string
+		edit func(*SystemNetwork)
+		want []string
+	}{
+		{
+			name: "unchanged",
+			host: "192.168.1.10",
+			edit: func(n *SystemNetwork) {},
+		},
+		{
+			name: "dns only",
+			host: "192.168.1.10:443",
+			edit: func(n *SystemNetwork) { n.Dns1 = net.ParseIP("192.168.1.53") },
+		},
+		{
+			name: "ipv4 change via ipv4",
+			host: "192.168.1.10",
+			edit: func(n *SystemNetwork) { n.Addr = net.ParseIP("192.168.1.20") },
+			want: []string{"IPv4 address changes from 192.168.1.10 to 192.168.1.20"},
+		},
+		{
+			name: "ipv4 change via hostname",
+			host: "unleashed.example.com",
+			edit: func(n *SystemNetwork) { n.Addr = net.ParseIP("192.168.1.20") },
+			want: []string{"IPv4 address changes from 192.168.1.10 to 192.168.1.20"},
+		},
+		{
+			name: "ipv4 change via ipv6",
+			host: "[2001:db8::10]:443",
+			edit: func(n *SystemNetwork) { n.Addr = net.ParseIP("192.168.1.20") },
+		},
+		{
+			name: "ipv4 change via other ipv4",
+			host: "203.0.113.5",
+			edit: func(n *SystemNetwork) { n.ByDhcp = true },
+		},
+		{
+			name: "dhcp",
+			host: "192.168.1.10",
+			edit: func(n *SystemNetwork) { n.ByDhcp = true },
+			want: []string{"switching to DHCP will change the device's IPv4 address"},
+		},
+		{
+			name: "gateway outside subnet",
+			host: "192.168.1.10",
+			edit: func(n *SystemNetwork) { n.Gateway = net.ParseIP("10.0.0.1") },
+			want: []string{"gateway 10.0.0.1 is outside 192.168.1.0/24"},
+		},
+		{
+			name: "ipv6 change via ipv6",
+			host: "2001:db8::10",
+			edit: func(n *SystemNetwork) { n.Ipv6Addr = net.ParseIP("2001:db8::20") },
+			want: []string{"IPv6 address changes from 2001:db8::10 to 2001:db8::20"},
+		},
+		{
+			name: "ipv6 change via ipv4",
+			host: "192.168.1.10",
+			edit: func(n *SystemNetwork) { n.Ipv6Addr = net.ParseIP("2001:db8::20") },
+		},
+		{
+			name: "mode and vlan",
+			host: "203.0.113.5",
+			edit: func(n *SystemNetwork) {
+				n.Ipmode = "1"
+				n.Vlan = 10
+			},
+			want: []string{`IP mode changes from "3" to "1"`, "management VLAN changes from 1 to 10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := current
+			tt.edit(&settings)
+			assert.Equal(t, tt.want, networkWarnings(tt.host, current, settings))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// URLFiltering manages URL filtering profiles, which block web traffic by category or domain. Wlans refer to them by
+// Wlan.UrlfilteringPolicy.UrlfilteringID.
+type URLFiltering struct {
+	c *Client
+}
+
+func (c *Client) URLFiltering() URLFiltering {
+	return URLFiltering{c}
+}
+
+type URLFilteringProfile struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+
+	// BlockedCategories lists the IDs of blocked URLCategory values.
+	BlockedCategories []int `xml:"blocked-category"`
+	// AllowedDomains are never blocked, and BlockedDomains are always blocked. Each domain includes its subdomains.
+	AllowedDomains []string `xml:"whitelist"`
+	BlockedDomains []string `xml:"blacklist"`
+
+	// SafeSearch* enforce each search engine's safe search mode.
+	SafeSearchGoogle  EnabledBool `xml:"safe-search-google,attr"`
+	SafeSearchYoutube EnabledBool `xml:"safe-search-youtube,attr"`
+	SafeSearchBing    EnabledBool `xml:"safe-search-bing,attr"`
+}
+
+// URLCategory is a category of websites which a URLFilteringProfile can block.
+type URLCategory struct {
+	ID   int    `xml:"id,attr"`
+	Name string `xml:"name,attr"`
+}
+
+func (p URLFilteringProfile) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	for _, domains := range [][]string{p.AllowedDomains, p.BlockedDomains} {
+		for _, domain := range domains {
+			if err := validDomainName(domain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u URLFiltering) List(ctx context.Context) ([]URLFilteringProfile, error) {
+	var resp struct {
+		XMLName  xml.Name              `xml:"urlfiltering-policy-list"`
+		Profiles []URLFilteringProfile `xml:"urlfiltering-policy"`
+	}
+
+	if err := u.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "urlfiltering-policy-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Profiles, nil
+	}
+}
+
+// Categories returns the categories available to URLFilteringProfile.BlockedCategories.
+func (u URLFiltering) Categories(ctx context.Context) ([]URLCategory, error) {
+	var resp struct {
+		XMLName    xml.Name      `xml:"urlfiltering-category-list"`
+		Categories []URLCategory `xml:"category"`
+	}
+
+	if err := u.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "urlfiltering-category-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Categories, nil
+	}
+}
+
+// Create creates a URLFilteringProfile, returning the created record.
+func (u URLFiltering) Create(ctx context.Context, profile URLFilteringProfile) (*URLFilteringProfile, error) {
+	var req struct {
+		XMLName xml.Name `xml:"urlfiltering-policy"`
+		URLFilteringProfile
+	}
+	req.URLFilteringProfile = profile
+	req.URLFilteringProfile.ID = 0 // ensure we don't specify one
+
+	if err := req.URLFilteringProfile.validate(); err != nil {
+		return nil, fmt.Errorf("invalid URLFilteringProfile: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"urlfiltering-policy"`
+		URLFilteringProfile
+	}
+
+	if err := u.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "urlfiltering-policy-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.URLFilteringProfile, nil
+	}
+}
+
+// Update updates a URLFilteringProfile, replacing the record.
+func (u URLFiltering) Update(ctx context.Context, profile URLFilteringProfile) error {
+	req := struct {
+		XMLName xml.Name `xml:"urlfiltering-policy"`
+		URLFilteringProfile
+	}{
+		URLFilteringProfile: profile,
+	}
+
+	if err := req.URLFilteringProfile.validate(); err != nil {
+		return fmt.Errorf("invalid URLFilteringProfile: %v", err)
+	}
+
+	return u.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "urlfiltering-policy-list",
+	}, &req, nil)
+}
+
+// Delete a URLFilteringProfile by ID. It must not be in use by any Wlan.
+func (u URLFiltering) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"urlfiltering-policy"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return u.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "urlfiltering-policy-list",
+	}, &req, nil)
+}
+
+// Bind enables URL filtering on the named Wlan using the URLFilteringProfile with the given ID.
+func (u URLFiltering) Bind(ctx context.Context, id int, wlanName string) error {
+	return u.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		policy := &wlan.UrlfilteringPolicy
+		if policy.UrlfilteringEnabled && policy.UrlfilteringID == id {
+			return false
+		}
+		policy.UrlfilteringEnabled = true
+		policy.UrlfilteringID = id
+		return true
+	})
+}
+
+// Unbind disables URL filtering on the named Wlan.
+func (u URLFiltering) Unbind(ctx context.Context, wlanName string) error {
+	return u.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		policy := &wlan.UrlfilteringPolicy
+		if !policy.UrlfilteringEnabled && policy.UrlfilteringID == 0 {
+			return false
+		}
+		policy.UrlfilteringEnabled = false
+		policy.UrlfilteringID = 0
+		return true
+	})
+}
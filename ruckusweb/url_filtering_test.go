@@ -0,0 +1,57 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLFilteringProfileValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*URLFilteringProfile)
+		want string
+	}{
+		{"valid", func(p *URLFilteringProfile) {}, ""},
+		{"no domains", func(p *URLFilteringProfile) {
+			p.AllowedDomains = nil
+			p.BlockedDomains = nil
+		}, ""},
+		{"no name", func(p *URLFilteringProfile) { p.Name = "" }, "name must be set"},
+		{"empty allowed domain", func(p *URLFilteringProfile) {
+			p.AllowedDomains = append(p.AllowedDomains, "")
+		}, "domain must not be empty"},
+		{"allowed URL", func(p *URLFilteringProfile) {
+			p.AllowedDomains = []string{"https://intranet.example.com"}
+		}, `invalid domain "https://intranet.example.com": must be a bare domain name like "example.com"`},
+		{"blocked path", func(p *URLFilteringProfile) {
+			p.BlockedDomains = []string{"example.net/games"}
+		}, `invalid domain "example.net/games": must be a bare domain name like "example.com"`},
+		{"blocked space", func(p *URLFilteringProfile) {
+			p.BlockedDomains = []string{"example net"}
+		}, `invalid domain "example net": must be a bare domain name like "example.com"`},
+		{"blocked empty label", func(p *URLFilteringProfile) {
+			p.BlockedDomains = []string{"example..net"}
+		}, `invalid domain "example..net": labels must be 1 to 63 letters, digits or inner hyphens`},
+		{"blocked wildcard", func(p *URLFilteringProfile) {
+			p.BlockedDomains = []string{"*.example.net"}
+		}, `invalid domain "*.example.net": labels must be 1 to 63 letters, digits or inner hyphens`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := URLFilteringProfile{
+				Name:              "staff",
+				BlockedCategories: []int{1, 2},
+				AllowedDomains:    []string{"intranet.example.com"},
+				BlockedDomains:    []string{"example.net"},
+			}
+			tt.edit(&profile)
+			err := profile.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
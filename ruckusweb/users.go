@@ -0,0 +1,276 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Users manages the local user database, which authenticates 802.1X, web authentication and guest pass generation
+// without an external AAA server.
+type Users struct {
+	c *Client
+}
+
+func (c *Client) Users() Users {
+	return Users{c}
+}
+
+type User struct {
+	ID       int    `xml:"id,attr,omitempty"`
+	Name     string `xml:"name,attr"`
+	FullName string `xml:"fullname,attr"`
+	// XPassword is write-only: it is empty when listing users. Updates with an empty XPassword keep the existing
+	// password.
+	XPassword string `xml:"x-password,attr,omitempty"`
+	// RoleID refers to a Role.
+	RoleID int `xml:"role-id,attr"`
+}
+
+func (u User) validate() error {
+	if len(u.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if strings.ContainsAny(u.Name, " \t") {
+		return errors.New("name must not contain whitespace")
+	}
+	return nil
+}
+
+func (u Users) List(ctx context.Context) ([]User, error) {
+	var resp struct {
+		XMLName xml.Name `xml:"user-list"`
+		User    []User   `xml:"user"`
+	}
+
+	if err := u.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "user-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		for i := range resp.User {
+			resp.User[i].XPassword = ""
+		}
+		return resp.User, nil
+	}
+}
+
+// Create creates a User, returning the created record.
+func (u Users) Create(ctx context.Context, user User) (*User, error) {
+	var req struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}
+	req.User = user
+	req.User.ID = 0 // ensure we don't specify one
+
+	if err := req.User.validate(); err != nil {
+		return nil, fmt.Errorf("invalid User: %v", err)
+	} else if len(req.User.XPassword) == 0 {
+		return nil, errors.New("invalid User: password must be set")
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}
+
+	if err := u.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "user-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		resp.User.XPassword = ""
+		return &resp.User, nil
+	}
+}
+
+// Update updates a User, replacing the record.
+func (u Users) Update(ctx context.Context, user User) error {
+	req := struct {
+		XMLName xml.Name `xml:"user"`
+		User
+	}{
+		User: user,
+	}
+
+	if err := req.User.validate(); err != nil {
+		return fmt.Errorf("invalid User: %v", err)
+	}
+
+	return u.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "user-list",
+	}, &req, nil)
+}
+
+// Delete a User by ID.
+func (u Users) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"user"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return u.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "user-list",
+	}, &req, nil)
+}
+
+// usersCSVHeader is the header row of the CSV format used by ImportCSV and ExportCSV.
+var usersCSVHeader = []string{"username", "full_name", "password", "role"}
+
+// csvUser is a row of a users CSV file, referring to its role by name.
+type csvUser struct {
+	Name, FullName, Password, Role string
+}
+
+func readUsersCSV(r io.Reader) ([]csvUser, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(usersCSVHeader)
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+	for i, name := range usersCSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), name) {
+			return nil, fmt.Errorf("invalid CSV header: expected %q", strings.Join(usersCSVHeader, ","))
+		}
+	}
+
+	var users []csvUser
+	seen := make(map[string]bool)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return users, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		// Passwords may legitimately start or end with spaces, so only the other columns are trimmed
+		user := csvUser{
+			Name:     strings.TrimSpace(record[0]),
+			FullName: strings.TrimSpace(record[1]),
+			Password: record[2],
+			Role:     strings.TrimSpace(record[3]),
+		}
+		if user.Name == "" {
+			return nil, fmt.Errorf("line %d: username must be set", line)
+		} else if seen[user.Name] {
+			return nil, fmt.Errorf("line %d: duplicate username %q", line, user.Name)
+		}
+		seen[user.Name] = true
+		users = append(users, user)
+	}
+}
+
+// ImportCSV creates or updates users from CSV with the columns username, full_name, password and role, preceded by a
+// header row. Existing users are matched by username, and keep their password if the password column is empty. Roles
+// are referred to by name; an empty role selects the "Default" role. Spaces around the username, full name and role
+// are ignored, but passwords are used exactly as written.
+//
+// The whole file is validated before any changes are made. ImportCSV returns the number of users created and updated.
+func (u Users) ImportCSV(ctx context.Context, r io.Reader) (created, updated int, err error) {
+	rows, err := readUsersCSV(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	roles, err := u.c.Roles().List(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	roleIDs := make(map[string]int, len(roles))
+	for _, role := range roles {
+		roleIDs[role.Name] = role.ID
+	}
+
+	existing, err := u.List(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	byName := make(map[string]User, len(existing))
+	for _, user := range existing {
+		byName[user.Name] = user
+	}
+
+	users := make([]User, len(rows))
+	for i, row := range rows {
+		roleName := row.Role
+		if roleName == "" {
+			roleName = "Default"
+		}
+		roleID, ok := roleIDs[roleName]
+		if !ok {
+			return 0, 0, fmt.Errorf("user %q: unknown role %q", row.Name, roleName)
+		}
+
+		user, exists := byName[row.Name]
+		if !exists && row.Password == "" {
+			return 0, 0, fmt.Errorf("user %q: password must be set for new users", row.Name)
+		}
+		user.Name = row.Name
+		user.FullName = row.FullName
+		user.XPassword = row.Password
+		user.RoleID = roleID
+		if err := user.validate(); err != nil {
+			return 0, 0, fmt.Errorf("user %q: %v", row.Name, err)
+		}
+		users[i] = user
+	}
+
+	for _, user := range users {
+		if user.ID == 0 {
+			if _, err := u.Create(ctx, user); err != nil {
+				return created, updated, fmt.Errorf("creating user %q: %w", user.Name, err)
+			}
+			created++
+		} else {
+			if err := u.Update(ctx, user); err != nil {
+				return created, updated, fmt.Errorf("updating user %q: %w", user.Name, err)
+			}
+			updated++
+		}
+	}
+	return created, updated, nil
+}
+
+// ExportCSV writes every user as CSV in the format read by ImportCSV. Passwords cannot be read back, so the password
+// column is empty.
+func (u Users) ExportCSV(ctx context.Context, w io.Writer) error {
+	users, err := u.List(ctx)
+	if err != nil {
+		return err
+	}
+	roles, err := u.c.Roles().List(ctx)
+	if err != nil {
+		return err
+	}
+	roleNames := make(map[int]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(usersCSVHeader); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := cw.Write([]string{user.Name, user.FullName, "", roleNames[user.RoleID]}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,82 @@
+package ruckusweb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadUsersCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []csvUser
+		err   string
+	}{
+		{
+			"valid",
+			"username,full_name,password,role\nalice,Alice Smith,hunter2,Staff\nbob, Bob,,\n",
+			[]csvUser{
+				{Name: "alice", FullName: "Alice Smith", Password: "hunter2", Role: "Staff"},
+				{Name: "bob", FullName: "Bob"},
+			},
+			"",
+		},
+		{
+			"password spaces",
+			"username,full_name,password,role\n carol , Carol ,  two words , Staff \n",
+			[]csvUser{
+				{Name: "carol", FullName: "Carol", Password: "  two words ", Role: "Staff"},
+			},
+			"",
+		},
+		{
+			"header only",
+			"Username,Full_Name,Password,Role\n",
+			nil,
+			"",
+		},
+		{
+			"empty",
+			"",
+			nil,
+			"reading CSV header: EOF",
+		},
+		{
+			"wrong header",
+			"name,full_name,password,role\n",
+			nil,
+			`invalid CSV header: expected "username,full_name,password,role"`,
+		},
+		{
+			"missing column",
+			"username,full_name,password,role\nalice,Alice,hunter2\n",
+			nil,
+			"record on line 2: wrong number of fields",
+		},
+		{
+			"missing username",
+			"username,full_name,password,role\n,Alice,hunter2,\n",
+			nil,
+			"line 2: username must be set",
+		},
+		{
+			"duplicate username",
+			"username,full_name,password,role\nalice,,a,\nbob,,b,\nalice,,c,\n",
+			nil,
+			`line 4: duplicate username "alice"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readUsersCSV(strings.NewReader(tt.input))
+			if tt.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
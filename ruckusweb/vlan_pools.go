@@ -0,0 +1,252 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VlanList is a set of VLAN IDs, encoded as comma-separated IDs and ranges like "10-20,30".
+type VlanList []int
+
+func (l VlanList) MarshalText() ([]byte, error) {
+	sorted := append(VlanList(nil), l...)
+	sort.Ints(sorted)
+
+	var b []byte
+	for i := 0; i < len(sorted); {
+		if sorted[i] < 1 || sorted[i] > 4094 {
+			return nil, fmt.Errorf("invalid VLAN %d", sorted[i])
+		}
+		j := i
+		for j+1 < len(sorted) && sorted[j+1] <= sorted[j]+1 {
+			j++
+		}
+		if len(b) > 0 {
+			b = append(b, ',')
+		}
+		b = strconv.AppendInt(b, int64(sorted[i]), 10)
+		if sorted[j] != sorted[i] {
+			b = append(b, '-')
+			b = strconv.AppendInt(b, int64(sorted[j]), 10)
+		}
+		i = j + 1
+	}
+	return b, nil
+}
+
+func (l *VlanList) UnmarshalText(text []byte) error {
+	var out VlanList
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(string(text), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := parseVlan(first)
+		if err != nil {
+			return err
+		}
+		end := start
+		if isRange {
+			if end, err = parseVlan(last); err != nil {
+				return err
+			} else if end < start {
+				return fmt.Errorf("invalid VLAN range %q", part)
+			}
+		}
+		for vlan := start; vlan <= end; vlan++ {
+			if !seen[vlan] {
+				seen[vlan] = true
+				out = append(out, vlan)
+			}
+		}
+	}
+	sort.Ints(out)
+	*l = out
+	return nil
+}
+
+func parseVlan(s string) (int, error) {
+	vlan, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || vlan < 1 || vlan > 4094 {
+		return 0, fmt.Errorf("invalid VLAN %q", s)
+	}
+	return vlan, nil
+}
+
+type VlanPoolAlgorithm int
+
+const (
+	// VlanPoolAlgorithmMacHash assigns each station a VLAN by hashing its MAC address, so it gets the same VLAN every
+	// time it connects.
+	VlanPoolAlgorithmMacHash VlanPoolAlgorithm = iota
+	// VlanPoolAlgorithmRoundRobin assigns VLANs in turn, balancing stations across them.
+	VlanPoolAlgorithmRoundRobin
+)
+
+func (a VlanPoolAlgorithm) MarshalText() ([]byte, error) {
+	switch a {
+	case VlanPoolAlgorithmMacHash:
+		return []byte("MAC_HASH"), nil
+	case VlanPoolAlgorithmRoundRobin:
+		return []byte("ROUND_ROBIN"), nil
+	default:
+		return nil, fmt.Errorf("invalid VlanPoolAlgorithm %d", int(a))
+	}
+}
+
+func (a *VlanPoolAlgorithm) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "MAC_HASH":
+		*a = VlanPoolAlgorithmMacHash
+	case "ROUND_ROBIN":
+		*a = VlanPoolAlgorithmRoundRobin
+	default:
+		return fmt.Errorf("invalid VLAN pool algorithm: %q", string(text))
+	}
+	return nil
+}
+
+// MaxVlanPoolVlans is the maximum number of VLANs in a VlanPool.
+const MaxVlanPoolVlans = 64
+
+// VlanPools manages VLAN pools, which spread a Wlan's stations across several VLANs. Wlans refer to them by
+// Wlan.PoolID.
+type VlanPools struct {
+	c *Client
+}
+
+func (c *Client) VlanPools() VlanPools {
+	return VlanPools{c}
+}
+
+type VlanPool struct {
+	ID          int               `xml:"id,attr,omitempty"`
+	Name        string            `xml:"name,attr"`
+	Description string            `xml:"description,attr"`
+	Vlans       VlanList          `xml:"vlans,attr"`
+	Algorithm   VlanPoolAlgorithm `xml:"algo,attr"`
+}
+
+func (p VlanPool) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if len(p.Vlans) == 0 {
+		return errors.New("at least one VLAN must be set")
+	}
+	if len(p.Vlans) > MaxVlanPoolVlans {
+		return fmt.Errorf("too many VLANs: %d > %d", len(p.Vlans), MaxVlanPoolVlans)
+	}
+	if _, err := p.Vlans.MarshalText(); err != nil {
+		return err
+	}
+	if p.Algorithm != VlanPoolAlgorithmMacHash && p.Algorithm != VlanPoolAlgorithmRoundRobin {
+		return fmt.Errorf("invalid algorithm %d", int(p.Algorithm))
+	}
+	return nil
+}
+
+func (v VlanPools) List(ctx context.Context) ([]VlanPool, error) {
+	var resp struct {
+		XMLName xml.Name   `xml:"vlan-pool-list"`
+		Pools   []VlanPool `xml:"vlan-pool"`
+	}
+
+	if err := v.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "vlan-pool-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Pools, nil
+	}
+}
+
+// Create creates a VlanPool, returning the created record.
+func (v VlanPools) Create(ctx context.Context, pool VlanPool) (*VlanPool, error) {
+	var req struct {
+		XMLName xml.Name `xml:"vlan-pool"`
+		VlanPool
+	}
+	req.VlanPool = pool
+	req.VlanPool.ID = 0 // ensure we don't specify one
+
+	if err := req.VlanPool.validate(); err != nil {
+		return nil, fmt.Errorf("invalid VlanPool: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"vlan-pool"`
+		VlanPool
+	}
+
+	if err := v.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "vlan-pool-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.VlanPool, nil
+	}
+}
+
+// Update updates a VlanPool, replacing the record.
+func (v VlanPools) Update(ctx context.Context, pool VlanPool) error {
+	req := struct {
+		XMLName xml.Name `xml:"vlan-pool"`
+		VlanPool
+	}{
+		VlanPool: pool,
+	}
+
+	if err := req.VlanPool.validate(); err != nil {
+		return fmt.Errorf("invalid VlanPool: %v", err)
+	}
+
+	return v.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "vlan-pool-list",
+	}, &req, nil)
+}
+
+// Delete a VlanPool by ID. It must not be in use by any Wlan.
+func (v VlanPools) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"vlan-pool"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return v.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "vlan-pool-list",
+	}, &req, nil)
+}
+
+// Bind assigns stations on the named Wlan a VLAN from the VlanPool with the given ID, instead of Wlan.VlanID.
+func (v VlanPools) Bind(ctx context.Context, id int, wlanName string) error {
+	return v.bind(ctx, wlanName, strconv.Itoa(id))
+}
+
+// Unbind returns the named Wlan to its single Wlan.VlanID.
+func (v VlanPools) Unbind(ctx context.Context, wlanName string) error {
+	return v.bind(ctx, wlanName, "")
+}
+
+func (v VlanPools) bind(ctx context.Context, wlanName string, poolID string) error {
+	return v.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		if wlan.PoolID == poolID {
+			return false
+		}
+		wlan.PoolID = poolID
+		return true
+	})
+}
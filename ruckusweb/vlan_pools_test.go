@@ -0,0 +1,80 @@
+package ruckusweb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVlanList(t *testing.T) {
+	tests := []struct {
+		text  string
+		vlans VlanList
+		// canonical is the re-encoded text, if different
+		canonical string
+		err       string
+	}{
+		{text: "", vlans: nil},
+		{text: "10", vlans: VlanList{10}},
+		{text: "10-12,30", vlans: VlanList{10, 11, 12, 30}},
+		{text: "30, 10-11,12", vlans: VlanList{10, 11, 12, 30}, canonical: "10-12,30"},
+		{text: "1,3,5-6,4094", vlans: VlanList{1, 3, 5, 6, 4094}},
+		{text: "5-7,6-8", vlans: VlanList{5, 6, 7, 8}, canonical: "5-8"},
+		{text: "0", err: `invalid VLAN "0"`},
+		{text: "4095", err: `invalid VLAN "4095"`},
+		{text: "20-10", err: `invalid VLAN range "20-10"`},
+		{text: "ten", err: `invalid VLAN "ten"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			var got VlanList
+			err := got.UnmarshalText([]byte(tt.text))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.vlans, got)
+
+			want := tt.canonical
+			if want == "" {
+				want = tt.text
+			}
+			text, err := got.MarshalText()
+			assert.NoError(t, err)
+			assert.Equal(t, want, string(text))
+		})
+	}
+}
+
+func TestVlanPoolValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		pool VlanPool
+		want string
+	}{
+		{"valid", VlanPool{Name: "p", Vlans: VlanList{10, 11}, Algorithm: VlanPoolAlgorithmRoundRobin}, ""},
+		{"no vlans", VlanPool{Name: "p"}, "at least one VLAN must be set"},
+		{"invalid vlan", VlanPool{Name: "p", Vlans: VlanList{4095}}, `invalid VLAN 4095`},
+		{"invalid algorithm", VlanPool{Name: "p", Vlans: VlanList{10}, Algorithm: VlanPoolAlgorithm(7)}, "invalid algorithm 7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pool.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVlanPoolAlgorithmMarshalText(t *testing.T) {
+	text, err := VlanPoolAlgorithmRoundRobin.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "ROUND_ROBIN", string(text))
+
+	_, err = VlanPoolAlgorithm(7).MarshalText()
+	assert.EqualError(t, err, "invalid VlanPoolAlgorithm 7")
+}
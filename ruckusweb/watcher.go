@@ -0,0 +1,260 @@
+package ruckusweb
+
+import (
+	"context"
+	"net"
+	"sort"
+	"time"
+)
+
+type WatchEventType int
+
+const (
+	// WatchEventError indicates a poll failed. The Watcher keeps polling, and once it succeeds it reports everything
+	// that changed in the meantime.
+	WatchEventError WatchEventType = iota
+	WatchEventStationAssociated
+	WatchEventStationDisassociated
+	// WatchEventStationRoamed indicates a station moved to a different AP or BSSID.
+	WatchEventStationRoamed
+	// WatchEventStationIPAcquired indicates a station gained an IPv4 address or changed to a different one.
+	WatchEventStationIPAcquired
+	WatchEventAPOnline
+	WatchEventAPOffline
+	WatchEventAPChannelChanged
+	WatchEventAPMeshUplinkChanged
+)
+
+func (t WatchEventType) String() string {
+	switch t {
+	case WatchEventError:
+		return "error"
+	case WatchEventStationAssociated:
+		return "station-associated"
+	case WatchEventStationDisassociated:
+		return "station-disassociated"
+	case WatchEventStationRoamed:
+		return "station-roamed"
+	case WatchEventStationIPAcquired:
+		return "station-ip-acquired"
+	case WatchEventAPOnline:
+		return "ap-online"
+	case WatchEventAPOffline:
+		return "ap-offline"
+	case WatchEventAPChannelChanged:
+		return "ap-channel-changed"
+	case WatchEventAPMeshUplinkChanged:
+		return "ap-mesh-uplink-changed"
+	default:
+		return "unknown"
+	}
+}
+
+// WatchEvent describes a change observed by a Watcher.
+type WatchEvent struct {
+	Type WatchEventType
+	At   time.Time
+
+	// Station is set for station events. For WatchEventStationDisassociated it is the last state seen.
+	Station *Station
+	// PreviousStation is set for WatchEventStationRoamed and WatchEventStationIPAcquired.
+	PreviousStation *Station
+
+	// AP is set for AP events. For WatchEventAPOffline it may be the last state seen.
+	AP *APStatus
+	// PreviousAP is set for WatchEventAPChannelChanged and WatchEventAPMeshUplinkChanged.
+	PreviousAP *APStatus
+	// RadioBand is set for WatchEventAPChannelChanged, e.g. "2.4g" or "5g".
+	RadioBand string
+
+	// Err is set for WatchEventError.
+	Err error
+}
+
+// Watcher polls stations and APs and reports changes between polls.
+type Watcher struct {
+	c        *Client
+	interval time.Duration
+}
+
+// DefaultWatchInterval is used by Watcher when interval is not positive.
+const DefaultWatchInterval = 30 * time.Second
+
+// Watcher returns a Watcher which polls every interval, or every DefaultWatchInterval if interval is 0.
+func (c *Client) Watcher(interval time.Duration) Watcher {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	return Watcher{c, interval}
+}
+
+// Watch polls until ctx is cancelled, sending events on the returned channel, which is closed when Watch stops.
+//
+// The first successful poll reports every station as associated and every connected AP as online, so that consumers
+// can build their initial state from the event stream alone.
+func (w Watcher) Watch(ctx context.Context) <-chan WatchEvent {
+	ch := make(chan WatchEvent)
+	go func() {
+		defer close(ch)
+
+		t := time.NewTicker(w.interval)
+		defer t.Stop()
+
+		var stations []Station
+		var aps []APStatus
+		for {
+			newAps, err := w.c.APs().ListStatuses(ctx)
+			var newStations []Station
+			if err == nil {
+				newStations, err = w.c.Stations().List(ctx)
+			}
+
+			var events []WatchEvent
+			now := time.Now()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				// Sessions don't survive a reboot, so log in again next time
+				w.c.resetLogin()
+				events = []WatchEvent{{Type: WatchEventError, At: now, Err: err}}
+			} else {
+				events = append(diffAPs(now, aps, newAps), diffStations(now, stations, newStations)...)
+				aps, stations = newAps, newStations
+			}
+
+			for _, event := range events {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- event:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return ch
+}
+
+func diffStations(at time.Time, old, new []Station) []WatchEvent {
+	oldByMac := make(map[string]*Station, len(old))
+	for i := range old {
+		oldByMac[net.HardwareAddr(old[i].Mac).String()] = &old[i]
+	}
+
+	var events []WatchEvent
+	seen := make(map[string]bool, len(new))
+	for i := range new {
+		station := &new[i]
+		mac := net.HardwareAddr(station.Mac).String()
+		seen[mac] = true
+
+		prev, ok := oldByMac[mac]
+		if !ok {
+			events = append(events, WatchEvent{Type: WatchEventStationAssociated, At: at, Station: station})
+			continue
+		}
+
+		if !macEqual(prev.Ap, station.Ap) || !macEqual(prev.VapMac, station.VapMac) {
+			events = append(events, WatchEvent{Type: WatchEventStationRoamed, At: at, Station: station, PreviousStation: prev})
+		}
+		if hasIP(station.Ip) && !station.Ip.Equal(prev.Ip) {
+			events = append(events, WatchEvent{Type: WatchEventStationIPAcquired, At: at, Station: station, PreviousStation: prev})
+		}
+	}
+
+	for mac, prev := range oldByMac {
+		if !seen[mac] {
+			events = append(events, WatchEvent{Type: WatchEventStationDisassociated, At: at, Station: prev})
+		}
+	}
+
+	sortWatchEvents(events)
+	return events
+}
+
+// apConnected returns true if the AP is connected to the master.
+func apConnected(ap APStatus) bool {
+	return ap.State == "1"
+}
+
+func diffAPs(at time.Time, old, new []APStatus) []WatchEvent {
+	oldByMac := make(map[string]*APStatus, len(old))
+	for i := range old {
+		oldByMac[net.HardwareAddr(old[i].Mac).String()] = &old[i]
+	}
+
+	var events []WatchEvent
+	seen := make(map[string]bool, len(new))
+	for i := range new {
+		ap := &new[i]
+		mac := net.HardwareAddr(ap.Mac).String()
+		seen[mac] = true
+
+		prev, ok := oldByMac[mac]
+		wasConnected := ok && apConnected(*prev)
+		if apConnected(*ap) && !wasConnected {
+			events = append(events, WatchEvent{Type: WatchEventAPOnline, At: at, AP: ap})
+		} else if !apConnected(*ap) && wasConnected {
+			events = append(events, WatchEvent{Type: WatchEventAPOffline, At: at, AP: ap})
+		}
+		if !ok || !apConnected(*ap) || !wasConnected {
+			continue
+		}
+
+		prevChannels := make(map[string]int, len(prev.Radio))
+		for _, radio := range prev.Radio {
+			prevChannels[radio.RadioBand] = radio.Channel
+		}
+		for _, radio := range ap.Radio {
+			if channel, ok := prevChannels[radio.RadioBand]; ok && channel != radio.Channel {
+				events = append(events, WatchEvent{Type: WatchEventAPChannelChanged, At: at, AP: ap, PreviousAP: prev, RadioBand: radio.RadioBand})
+			}
+		}
+
+		if prev.MeshUplinkType != ap.MeshUplinkType || prev.MeshDepth != ap.MeshDepth {
+			events = append(events, WatchEvent{Type: WatchEventAPMeshUplinkChanged, At: at, AP: ap, PreviousAP: prev})
+		}
+	}
+
+	for mac, prev := range oldByMac {
+		if !seen[mac] && apConnected(*prev) {
+			events = append(events, WatchEvent{Type: WatchEventAPOffline, At: at, AP: prev})
+		}
+	}
+
+	sortWatchEvents(events)
+	return events
+}
+
+// sortWatchEvents orders events by MAC address and then by type, so that each poll reports changes in a stable order.
+func sortWatchEvents(events []WatchEvent) {
+	key := func(e WatchEvent) string {
+		if e.Station != nil {
+			return net.HardwareAddr(e.Station.Mac).String()
+		} else if e.AP != nil {
+			return net.HardwareAddr(e.AP.Mac).String()
+		}
+		return ""
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		ki, kj := key(events[i]), key(events[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return events[i].Type < events[j].Type
+	})
+}
+
+func macEqual(a, b MacAddress) bool {
+	return net.HardwareAddr(a).String() == net.HardwareAddr(b).String()
+}
+
+func hasIP(ip net.IP) bool {
+	return len(ip) > 0 && !ip.IsUnspecified()
+}
@@ -0,0 +1,131 @@
+package ruckusweb
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustMac(s string) MacAddress {
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		panic(err)
+	}
+	return MacAddress(addr)
+}
+
+func watchEventTypes(events []WatchEvent) []WatchEventType {
+	var types []WatchEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func TestDiffStations(t *testing.T) {
+	a := mustMac("00:00:00:00:00:0a")
+	b := mustMac("00:00:00:00:00:0b")
+	c := mustMac("00:00:00:00:00:0c")
+	ap1 := mustMac("00:00:00:00:01:01")
+	ap2 := mustMac("00:00:00:00:01:02")
+
+	tests := []struct {
+		name string
+		old  []Station
+		new  []Station
+		want []WatchEventType
+	}{
+		{
+			"initial",
+			nil,
+			[]Station{{Mac: a, Ap: ap1}},
+			[]WatchEventType{WatchEventStationAssociated},
+		},
+		{
+			"unchanged",
+			[]Station{{Mac: a, Ap: ap1, Ip: net.IPv4(10, 0, 0, 1)}},
+			[]Station{{Mac: a, Ap: ap1, Ip: net.IPv4(10, 0, 0, 1)}},
+			nil,
+		},
+		{
+			"roamed",
+			[]Station{{Mac: a, Ap: ap1}},
+			[]Station{{Mac: a, Ap: ap2}},
+			[]WatchEventType{WatchEventStationRoamed},
+		},
+		{
+			"ip acquired",
+			[]Station{{Mac: a, Ap: ap1}},
+			[]Station{{Mac: a, Ap: ap1, Ip: net.IPv4(10, 0, 0, 1)}},
+			[]WatchEventType{WatchEventStationIPAcquired},
+		},
+		{
+			"ip lost",
+			[]Station{{Mac: a, Ap: ap1, Ip: net.IPv4(10, 0, 0, 1)}},
+			[]Station{{Mac: a, Ap: ap1}},
+			nil,
+		},
+		{
+			"churn",
+			[]Station{{Mac: a, Ap: ap1}, {Mac: b, Ap: ap1}},
+			[]Station{{Mac: c, Ap: ap1}, {Mac: b, Ap: ap1}},
+			[]WatchEventType{WatchEventStationDisassociated, WatchEventStationAssociated},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffStations(time.Unix(0, 0), tt.old, tt.new)
+			assert.Equal(t, tt.want, watchEventTypes(got))
+		})
+	}
+}
+
+func TestDiffAPs(t *testing.T) {
+	ap1 := mustMac("00:00:00:00:01:01")
+
+	tests := []struct {
+		name string
+		old  []APStatus
+		new  []APStatus
+		want []WatchEventType
+	}{
+		{
+			"initial",
+			nil,
+			[]APStatus{{Mac: ap1, State: "1"}},
+			[]WatchEventType{WatchEventAPOnline},
+		},
+		{
+			"went offline",
+			[]APStatus{{Mac: ap1, State: "1"}},
+			[]APStatus{{Mac: ap1, State: "0"}},
+			[]WatchEventType{WatchEventAPOffline},
+		},
+		{
+			"disappeared",
+			[]APStatus{{Mac: ap1, State: "1"}},
+			nil,
+			[]WatchEventType{WatchEventAPOffline},
+		},
+		{
+			"channel changed",
+			[]APStatus{{Mac: ap1, State: "1", Radio: []APStatusRadio{{RadioBand: "5g", Channel: 36}}}},
+			[]APStatus{{Mac: ap1, State: "1", Radio: []APStatusRadio{{RadioBand: "5g", Channel: 149}}}},
+			[]WatchEventType{WatchEventAPChannelChanged},
+		},
+		{
+			"mesh uplink changed",
+			[]APStatus{{Mac: ap1, State: "1", MeshDepth: 1}},
+			[]APStatus{{Mac: ap1, State: "1", MeshDepth: 2}},
+			[]WatchEventType{WatchEventAPMeshUplinkChanged},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffAPs(time.Unix(0, 0), tt.old, tt.new)
+			assert.Equal(t, tt.want, watchEventTypes(got))
+		})
+	}
+}
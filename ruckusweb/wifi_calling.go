@@ -0,0 +1,203 @@
+package ruckusweb
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// WifiCallingProfiles manages Wi-Fi calling profiles, which identify a carrier's ePDG gateways so that calls to them
+// can be prioritized. Wlans refer to them by Wlan.WificallingPolicy.ProfileID.
+type WifiCallingProfiles struct {
+	c *Client
+}
+
+func (c *Client) WifiCallingProfiles() WifiCallingProfiles {
+	return WifiCallingProfiles{c}
+}
+
+type WifiCallingProfile struct {
+	ID          int    `xml:"id,attr,omitempty"`
+	Name        string `xml:"name,attr"`
+	Description string `xml:"description,attr"`
+	// Carrier is the name of the mobile operator, e.g. "T-Mobile".
+	Carrier string `xml:"provider-name,attr"`
+	// Priority is "high" or "normal".
+	Priority string `xml:"priority,attr"`
+
+	Epdgs []WifiCallingEpdg `xml:"epdg"`
+}
+
+// WifiCallingEpdg is one of a carrier's ePDG gateways, identified by either Domain or Ip.
+type WifiCallingEpdg struct {
+	Domain string `xml:"domain,attr,omitempty"`
+	Ip     net.IP `xml:"ip,attr,omitempty"`
+}
+
+func (p WifiCallingProfile) validate() error {
+	if len(p.Name) == 0 {
+		return errors.New("name must be set")
+	}
+	if p.Priority != "high" && p.Priority != "normal" {
+		return fmt.Errorf("priority must be \"high\" or \"normal\", not %q", p.Priority)
+	}
+	if len(p.Epdgs) == 0 {
+		return errors.New("at least one ePDG must be set")
+	}
+	for i, epdg := range p.Epdgs {
+		if (epdg.Domain == "") == (epdg.Ip == nil) {
+			return fmt.Errorf("ePDG %d: exactly one of domain and IP must be set", i+1)
+		}
+		if epdg.Domain != "" {
+			if err := validDomainName(epdg.Domain); err != nil {
+				return fmt.Errorf("ePDG %d: %v", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (w WifiCallingProfiles) List(ctx context.Context) ([]WifiCallingProfile, error) {
+	var resp struct {
+		XMLName  xml.Name             `xml:"wificalling-profile-list"`
+		Profiles []WifiCallingProfile `xml:"wificalling-profile"`
+	}
+
+	if err := w.c.conf(ctx, confReq{
+		Action:   "getconf",
+		DECRYPTX: "false",
+		Comp:     "wificalling-profile-list",
+	}, nil, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Profiles, nil
+	}
+}
+
+// Create creates a WifiCallingProfile, returning the created record.
+func (w WifiCallingProfiles) Create(ctx context.Context, profile WifiCallingProfile) (*WifiCallingProfile, error) {
+	var req struct {
+		XMLName xml.Name `xml:"wificalling-profile"`
+		WifiCallingProfile
+	}
+	req.WifiCallingProfile = profile
+	req.WifiCallingProfile.ID = 0 // ensure we don't specify one
+
+	if err := req.WifiCallingProfile.validate(); err != nil {
+		return nil, fmt.Errorf("invalid WifiCallingProfile: %v", err)
+	}
+
+	var resp struct {
+		XMLName xml.Name `xml:"wificalling-profile"`
+		WifiCallingProfile
+	}
+
+	if err := w.c.conf(ctx, confReq{
+		Action: "addobj",
+		Comp:   "wificalling-profile-list",
+	}, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return &resp.WifiCallingProfile, nil
+	}
+}
+
+// Update updates a WifiCallingProfile, replacing the record.
+func (w WifiCallingProfiles) Update(ctx context.Context, profile WifiCallingProfile) error {
+	req := struct {
+		XMLName xml.Name `xml:"wificalling-profile"`
+		WifiCallingProfile
+	}{
+		WifiCallingProfile: profile,
+	}
+
+	if err := req.WifiCallingProfile.validate(); err != nil {
+		return fmt.Errorf("invalid WifiCallingProfile: %v", err)
+	}
+
+	return w.c.conf(ctx, confReq{
+		Action: "updobj",
+		Comp:   "wificalling-profile-list",
+	}, &req, nil)
+}
+
+// Delete a WifiCallingProfile by ID. It must not be in use by any Wlan.
+func (w WifiCallingProfiles) Delete(ctx context.Context, id int) error {
+	var req struct {
+		XMLName xml.Name `xml:"wificalling-profile"`
+		ID      int      `xml:"id,attr"`
+	}
+	req.ID = id
+
+	return w.c.conf(ctx, confReq{
+		Action: "delobj",
+		Comp:   "wificalling-profile-list",
+	}, &req, nil)
+}
+
+// Bind enables Wi-Fi calling prioritization on the named Wlan using the WifiCallingProfile with the given ID.
+func (w WifiCallingProfiles) Bind(ctx context.Context, id int, wlanName string) error {
+	return w.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		policy := &wlan.WificallingPolicy
+		if policy.WificallingEnabled && policy.ProfileID == id {
+			return false
+		}
+		policy.WificallingEnabled = true
+		policy.ProfileID = id
+		return true
+	})
+}
+
+// Unbind disables Wi-Fi calling prioritization on the named Wlan.
+func (w WifiCallingProfiles) Unbind(ctx context.Context, wlanName string) error {
+	return w.c.Wlans().edit(ctx, wlanName, func(wlan *Wlan) bool {
+		policy := &wlan.WificallingPolicy
+		if !policy.WificallingEnabled && policy.ProfileID == 0 {
+			return false
+		}
+		policy.WificallingEnabled = false
+		policy.ProfileID = 0
+		return true
+	})
+}
+
+// WifiCallingSession is a station currently exchanging traffic with an ePDG in a WifiCallingProfile.
+type WifiCallingSession struct {
+	Station   MacAddress `xml:"mac,attr"`
+	ProfileID int        `xml:"profile-id,attr"`
+	Carrier   string     `xml:"provider-name,attr"`
+	// Epdg is the domain or IP address of the gateway in use.
+	Epdg string `xml:"epdg,attr"`
+}
+
+// Sessions returns the stations currently using Wi-Fi calling on Wlans with a WifiCallingProfile.
+func (w WifiCallingProfiles) Sessions(ctx context.Context) ([]WifiCallingSession, error) {
+	var req struct {
+		XMLName     xml.Name `xml:"ajax-request"`
+		Action      string   `xml:"action,attr"`
+		Updater     string   `xml:"updater,attr"`
+		Comp        string   `xml:"comp,attr"`
+		Wificalling struct{} `xml:"wificalling-stat"`
+	}
+	req.Action = "getstat"
+	req.Comp = "stamgr"
+
+	var resp struct {
+		XMLName  xml.Name `xml:"ajax-response"`
+		Response struct {
+			Type            string `xml:"type,attr"`
+			ID              string `xml:"id,attr"`
+			WificallingStat struct {
+				Client []WifiCallingSession `xml:"client"`
+			} `xml:"wificalling-stat"`
+		} `xml:"response"`
+	}
+
+	if err := w.c.cmdstat(ctx, &req, &resp); err != nil {
+		return nil, err
+	} else {
+		return resp.Response.WificallingStat.Client, nil
+	}
+}
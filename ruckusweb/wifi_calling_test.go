@@ -0,0 +1,103 @@
+package ruckusweb
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDevice answers the login page, then responds to each XML request with the next of responses.
+type fakeDevice struct {
+	responses []string
+	requests  []string
+}
+
+func (d *fakeDevice) RoundTrip(req *http.Request) (*http.Response, error) {
+	respond := func(body string, header http.Header) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Header: header, Body: io.NopCloser(strings.NewReader(body)), Request: req}, nil
+	}
+	if req.URL.Path == "/admin/login.jsp" {
+		return respond(`<html><script>var csfrToken = 'token';</script></html>`, http.Header{"Set-Cookie": {"-ejs-session-=x; Path=/"}})
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, string(body))
+	if len(d.responses) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	response := d.responses[0]
+	d.responses = d.responses[1:]
+	return respond(response, nil)
+}
+
+func newFakeClient(d *fakeDevice) *Client {
+	c := NewClient(d, "unleashed", Credentials{Username: "admin", Password: "password"})
+	c.SetTraceLog(nil)
+	return c
+}
+
+func TestWifiCallingProfileValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *WifiCallingProfile)
+		want string
+	}{
+		{"valid", func(p *WifiCallingProfile) {}, ""},
+		{"priority", func(p *WifiCallingProfile) { p.Priority = "urgent" }, `priority must be "high" or "normal", not "urgent"`},
+		{"no ePDGs", func(p *WifiCallingProfile) { p.Epdgs = nil }, "at least one ePDG must be set"},
+		{"domain and IP", func(p *WifiCallingProfile) {
+			p.Epdgs[0].Ip = net.ParseIP("192.0.2.1")
+		}, "ePDG 1: exactly one of domain and IP must be set"},
+		{"neither domain nor IP", func(p *WifiCallingProfile) {
+			p.Epdgs = append(p.Epdgs, WifiCallingEpdg{})
+		}, "ePDG 3: exactly one of domain and IP must be set"},
+		{"domain URL", func(p *WifiCallingProfile) {
+			p.Epdgs[0].Domain = "https://epdg.example.com"
+		}, `ePDG 1: invalid domain "https://epdg.example.com": must be a bare domain name like "example.com"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := WifiCallingProfile{
+				Name:     "carrier",
+				Carrier:  "Example Mobile",
+				Priority: "high",
+				Epdgs: []WifiCallingEpdg{
+					{Domain: "epdg.epc.example.com"},
+					{Ip: net.ParseIP("198.51.100.10")},
+				},
+			}
+			tt.edit(&profile)
+			err := profile.validate()
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWifiCallingSessions(t *testing.T) {
+	device := &fakeDevice{responses: []string{`<ajax-response><response type="object" id="stamgr"><wificalling-stat>` +
+		`<client mac="00:00:00:00:00:01" profile-id="2" provider-name="Example Mobile" epdg="epdg.epc.example.com"/>` +
+		`<client mac="00:00:00:00:00:02" profile-id="2" provider-name="Example Mobile" epdg="198.51.100.10"/>` +
+		`</wificalling-stat></response></ajax-response>`}}
+
+	sessions, err := newFakeClient(device).WifiCallingProfiles().Sessions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []WifiCallingSession{
+		{Station: mustMac("00:00:00:00:00:01"), ProfileID: 2, Carrier: "Example Mobile", Epdg: "epdg.epc.example.com"},
+		{Station: mustMac("00:00:00:00:00:02"), ProfileID: 2, Carrier: "Example Mobile", Epdg: "198.51.100.10"},
+	}, sessions)
+	require.Len(t, device.requests, 1)
+	assert.Contains(t, device.requests[0], `<wificalling-stat></wificalling-stat>`)
+}
@@ -2,13 +2,21 @@ package ruckusweb
 
 import (
 	"bytes"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// attrElement is an element whose attributes are supplied at runtime, e.g. the filters of a getstat request.
+type attrElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+}
+
 type EnabledBool bool
 
 func (b EnabledBool) MarshalText() ([]byte, error) {
@@ -60,6 +68,11 @@ func (m MacAddress) MarshalText() ([]byte, error) {
 }
 
 func (m *MacAddress) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		// Some records leave MAC addresses blank rather than omitting them
+		*m = nil
+		return nil
+	}
 	if addr, err := net.ParseMAC(string(text)); err != nil {
 		return err
 	} else {
@@ -72,10 +85,13 @@ type Timestamp struct {
 	time.Time
 }
 
+// MarshalText encodes t as Unix seconds. The zero Timestamp is encoded as "0", which UnmarshalText decodes back to
+// the zero Timestamp rather than the Unix epoch.
 func (t Timestamp) MarshalText() ([]byte, error) {
-	var buffer []byte
-	strconv.AppendInt(buffer, t.Unix(), 10)
-	return buffer, nil
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	return strconv.AppendInt(nil, t.Unix(), 10), nil
 }
 
 func (t *Timestamp) UnmarshalText(text []byte) error {
@@ -83,7 +99,11 @@ func (t *Timestamp) UnmarshalText(text []byte) error {
 	if err != nil {
 		return err
 	}
-	t.Time = time.Unix(n, 0)
+	if n == 0 {
+		t.Time = time.Time{}
+	} else {
+		t.Time = time.Unix(n, 0)
+	}
 	return nil
 }
 
@@ -211,3 +231,35 @@ func (ts *RssiTimeSeries) UnmarshalText(text []byte) error {
 	}
 	return nil
 }
+
+// validDomainName checks that domain is a bare domain name, as used by URL filtering and Hotspot 2.0, rather than a URL
+// or a wildcard.
+func validDomainName(domain string) error {
+	if domain == "" {
+		return errors.New("domain must not be empty")
+	}
+	if strings.Contains(domain, "://") || strings.ContainsAny(domain, "/ \t") {
+		return fmt.Errorf("invalid domain %q: must be a bare domain name like \"example.com\"", domain)
+	}
+	if len(domain) > 253 {
+		return fmt.Errorf("invalid domain %q: longer than 253 characters", domain)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if !validDomainLabel(label) {
+			return fmt.Errorf("invalid domain %q: labels must be 1 to 63 letters, digits or inner hyphens", domain)
+		}
+	}
+	return nil
+}
+
+func validDomainLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package ruckusweb
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampText(t *testing.T) {
+	tests := []struct {
+		name string
+		time Timestamp
+		xml  string
+	}{
+		{"set", Timestamp{time.Unix(1700000000, 0)}, `<ap last-seen="1700000000"></ap>`},
+		{"zero", Timestamp{}, `<ap last-seen="0"></ap>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			type ap struct {
+				XMLName xml.Name  `xml:"ap"`
+				Time    Timestamp `xml:"last-seen,attr"`
+			}
+
+			b, err := xml.Marshal(&ap{Time: tt.time})
+			require.NoError(t, err)
+			assert.Equal(t, tt.xml, string(b))
+
+			var decoded ap
+			require.NoError(t, xml.Unmarshal(b, &decoded))
+			assert.True(t, decoded.Time.Equal(tt.time.Time))
+			assert.Equal(t, tt.time.IsZero(), decoded.Time.IsZero())
+		})
+	}
+}
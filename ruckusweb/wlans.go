@@ -120,10 +120,13 @@ enablePerSSIDDownlink: !(!e.qos || !e.qos.perssidDownlinkPreset || "0" === e.qos
 perssidDownlinkPreset: e.qos && e.qos.perssidDownlinkPreset || "0"
 */
 type WlanQos struct {
-	UplinkPreset          string `xml:"uplink-preset,attr"`
-	DownlinkPreset        string `xml:"downlink-preset,attr"`
-	PerssidUplinkPreset   int    `xml:"perssid-uplink-preset,attr"`
-	PerssidDownlinkPreset int    `xml:"perssid-downlink-preset,attr"`
+	// UplinkPreset and DownlinkPreset limit each station's throughput.
+	UplinkPreset   RateLimit `xml:"uplink-preset,attr"`
+	DownlinkPreset RateLimit `xml:"downlink-preset,attr"`
+	// PerssidUplinkPreset and PerssidDownlinkPreset limit the Wlan's total throughput on each AP in Mbps, up to
+	// MaxPerSsidRateLimitMbps, or 0 for no limit.
+	PerssidUplinkPreset   int `xml:"perssid-uplink-preset,attr"`
+	PerssidDownlinkPreset int `xml:"perssid-downlink-preset,attr"`
 }
 
 /*
@@ -258,7 +261,19 @@ func (s *WlanSchedule) UnmarshalXML(d *xml.Decoder, se xml.StartElement) error {
 	if text == nil {
 		return errors.New("invalid WLAN schedule: no value attribute")
 	}
+	if err := s.UnmarshalText(text); err != nil {
+		return err
+	}
+
+	var empty struct{}
+	return d.DecodeElement(&empty, &se)
+}
+
+func (s WlanSchedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
 
+func (s *WlanSchedule) UnmarshalText(text []byte) error {
 	words := bytes.SplitN(text, []byte{':'}, 28)
 	if len(words) < 28 {
 		return errors.New("invalid WLAN schedule: not enough words")
@@ -279,9 +294,7 @@ func (s *WlanSchedule) UnmarshalXML(d *xml.Decoder, se xml.StartElement) error {
 			return errors.New("invalid WLAN schedule: word has too many bits")
 		}
 	}
-
-	var empty struct{}
-	return d.DecodeElement(&empty, &se)
+	return nil
 }
 
 // WlanScheduleDay describes when a Wlan should be enabled in terms of 15-minute increments over a 24-hour day. Index 0
@@ -401,8 +414,25 @@ type Wlan struct {
 		WificallingEnabled EnabledBool `xml:"wificalling-enabled,attr"`
 		ProfileID          int         `xml:"profile-id,attr"`
 	} `xml:"wificalling-policy"`
+
+	// Policy6ID refers to an IPv6 L3ACL, like PolicyID does for IPv4.
+	Policy6ID string `xml:"policy6-id,attr,omitempty"`
+
+	// Hotspot20OperatorID refers to a Hotspot20Operator, making this a Passpoint Wlan, or 0 for none.
+	Hotspot20OperatorID int `xml:"hs20op-id,attr,omitempty"`
+
+	// HotspotID refers to the HotspotService of a Wlan with Usage WlanUsageHotspot.
+	HotspotID int `xml:"hotspot-id,attr,omitempty"`
 }
 
+const (
+	// WlanUsageStandard is the Usage of an ordinary Wlan.
+	WlanUsageStandard = "user"
+	// WlanUsageHotspot is the Usage of a Wlan whose stations log in through an external captive portal described by a
+	// HotspotService.
+	WlanUsageHotspot = "hotspot"
+)
+
 func (w Wlan) validate() error {
 	if len(w.Name) == 0 {
 		return errors.New("WLAN name must be set")
@@ -430,6 +460,20 @@ func (w Wlan) validate() error {
 		}
 	}
 
+	if w.Hotspot20OperatorID != 0 && w.Authentication != WlanAuthentication8021xEAP {
+		return errors.New("Hotspot 2.0 requires 802.1X authentication")
+	}
+
+	if w.Usage == WlanUsageHotspot && w.HotspotID == 0 {
+		return errors.New("hotspot WLANs require a HotspotID")
+	} else if w.Usage != WlanUsageHotspot && w.HotspotID != 0 {
+		return errors.New("HotspotID requires hotspot usage")
+	}
+
+	if err := w.Qos.validate(); err != nil {
+		return fmt.Errorf("WLAN QoS: %v", err)
+	}
+
 	return nil
 }
 
@@ -438,7 +482,7 @@ func NewWlan(name string) Wlan {
 		Name:               name,
 		Ssid:               name,
 		Description:        name,
-		Usage:              "user",
+		Usage:              WlanUsageStandard,
 		Authentication:     WlanAuthenticationOpen,
 		AcctUpdInterval:    10,
 		VlanID:             1,
@@ -457,8 +501,8 @@ func NewWlan(name string) Wlan {
 		PrecedenceID:       1,
 		QueuePriority:      true,
 		Qos: WlanQos{
-			UplinkPreset:          "DISABLE",
-			DownlinkPreset:        "DISABLE",
+			UplinkPreset:          RateLimitUnlimited,
+			DownlinkPreset:        RateLimitUnlimited,
 			PerssidUplinkPreset:   0,
 			PerssidDownlinkPreset: 0,
 		},
@@ -504,6 +548,8 @@ func (w Wlans) Create(ctx context.Context, wlan Wlan) (*Wlan, error) {
 
 	if err := req.Wlan.validate(); err != nil {
 		return nil, fmt.Errorf("invalid Wlan: %v", err)
+	} else if err := req.Wlan.Qos.validatePresets(); err != nil {
+		return nil, fmt.Errorf("invalid Wlan: QoS: %v", err)
 	}
 
 	var resp struct {
@@ -557,6 +603,43 @@ func (w Wlans) Delete(ctx context.Context, id int) error {
 	}, &req, nil)
 }
 
+// edit reads the named Wlan, applies fn, and writes the Wlan back if fn reports a change.
+func (w Wlans) edit(ctx context.Context, name string, fn func(wlan *Wlan) bool) error {
+	w.c.editM.Lock()
+	defer w.c.editM.Unlock()
+
+	wlans, err := w.List(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range wlans {
+		if wlans[i].Name == name {
+			if !fn(&wlans[i]) {
+				return nil
+			}
+			return w.Update(ctx, wlans[i])
+		}
+	}
+	return fmt.Errorf("WLAN %q not found", name)
+}
+
+// SetRateLimits sets the per-station uplink and downlink rate limits of the named Wlan. Each must be
+// RateLimitUnlimited or one of RateLimitPresets.
+func (w Wlans) SetRateLimits(ctx context.Context, name string, uplink, downlink RateLimit) error {
+	qos := WlanQos{UplinkPreset: uplink, DownlinkPreset: downlink}
+	if err := qos.validatePresets(); err != nil {
+		return fmt.Errorf("invalid Wlan QoS: %v", err)
+	}
+
+	return w.edit(ctx, name, func(wlan *Wlan) bool {
+		if wlan.Qos.UplinkPreset == uplink && wlan.Qos.DownlinkPreset == downlink {
+			return false
+		}
+		wlan.Qos.UplinkPreset, wlan.Qos.DownlinkPreset = uplink, downlink
+		return true
+	})
+}
+
 type WlanStatus struct {
 	ID        int    `xml:"id,attr"`
 	Ssid      string `xml:"ssid,attr"`
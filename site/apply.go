@@ -0,0 +1,257 @@
+package site
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+// Apply makes each change in the plan, stopping at the first error. The network should not be reconfigured by anything
+// else between Compute and Apply.
+func (p *Plan) Apply(ctx context.Context, c *ruckusweb.Client) error {
+	a := newApplier(c, p.state)
+	for _, change := range p.Changes {
+		if err := change.apply(ctx, a); err != nil {
+			return fmt.Errorf("%s %s %q: %w", change.Action, change.Kind, change.Name, err)
+		}
+	}
+	return nil
+}
+
+// applier tracks the IDs of objects by name, including those created while applying a plan.
+type applier struct {
+	c     *ruckusweb.Client
+	state *state
+
+	aaaIDs     map[string]int
+	wlanIDs    map[string]int
+	apGroupIDs map[string]int
+}
+
+func newApplier(c *ruckusweb.Client, s *state) *applier {
+	a := &applier{
+		c:          c,
+		state:      s,
+		aaaIDs:     make(map[string]int, len(s.aaa)),
+		wlanIDs:    make(map[string]int, len(s.wlans)),
+		apGroupIDs: make(map[string]int, len(s.apGroups)),
+	}
+	for _, server := range s.aaa {
+		a.aaaIDs[server.Name()] = server.ID()
+	}
+	for _, wlan := range s.wlans {
+		a.wlanIDs[wlan.Name] = wlan.ID
+	}
+	for _, group := range s.apGroups {
+		a.apGroupIDs[group.Name] = group.ID
+	}
+	return a
+}
+
+func (a *applier) createAAA(ctx context.Context, server AAAServer) error {
+	if created, err := a.c.AAA().Create(ctx, server.wire(nil)); err != nil {
+		return err
+	} else if created == nil {
+		return fmt.Errorf("creating AAA server %q returned no record", server.Name)
+	} else {
+		a.aaaIDs[server.Name] = created.ID()
+		return nil
+	}
+}
+
+func (a *applier) updateAAA(ctx context.Context, current ruckusweb.AaaServer, server AAAServer) error {
+	return a.c.AAA().Update(ctx, server.wire(current))
+}
+
+// wire returns the server as a ruckusweb.AaaServer, retaining any settings of current which AAAServer does not manage.
+func (s AAAServer) wire(current ruckusweb.AaaServer) ruckusweb.AaaServer {
+	if s.Type == "ad" {
+		out := ruckusweb.AaaActiveDirectory{Timeout: 3, Port: 389}
+		if existing, ok := current.(*ruckusweb.AaaActiveDirectory); ok {
+			out = *existing
+		}
+		out.AaaEntry.Name = s.Name
+		out.AaaEntry.Type = s.Type
+		out.GroupString = s.GroupAttribute
+		out.Server1 = s.Server
+		if s.Port != 0 {
+			out.Port = s.Port
+		}
+		out.GlobalCatalog = ruckusweb.EnabledBool(s.GlobalCatalog)
+		out.SearchBase = s.SearchBase
+		out.AdminDn = s.AdminDN
+		out.AdminPwd = s.AdminPassword
+		out.XAdminPwd = s.AdminPassword
+		return &out
+	}
+
+	out := ruckusweb.AaaRadius{Timeout: 3, Algorithm: "pap", FailoverRetry: 2, RetryConsecutivePacket: 6, RetryPrimaryInterval: 300}
+	if existing, ok := current.(*ruckusweb.AaaRadius); ok {
+		out = *existing
+	}
+	out.AaaEntry.Name = s.Name
+	out.AaaEntry.Type = s.Type
+	out.GroupString = s.GroupAttribute
+	out.PrimaryRadius = s.Primary.wire(out.PrimaryRadius)
+	out.SecondaryRadius = s.Secondary.wire(out.SecondaryRadius)
+	out.Backup = s.Secondary != nil
+	return &out
+}
+
+func (e *RadiusEndpoint) wire(current *ruckusweb.AaaRadiusEndpoint) *ruckusweb.AaaRadiusEndpoint {
+	if e == nil {
+		return nil
+	}
+	out := ruckusweb.AaaRadiusEndpoint{Timeout: 3, Retry: 2}
+	if current != nil {
+		out = *current
+	}
+	out.Ip = e.Address
+	out.Port = e.Port
+	out.Secret = e.Secret
+	out.XSecret = e.Secret
+	return &out
+}
+
+func (a *applier) createWLAN(ctx context.Context, wlan WLAN) error {
+	w, err := a.wlanWire(ruckusweb.NewWlan(wlan.Name), wlan)
+	if err != nil {
+		return err
+	}
+	if created, err := a.c.Wlans().Create(ctx, w); err != nil {
+		return err
+	} else {
+		a.wlanIDs[wlan.Name] = created.ID
+	}
+	return nil
+}
+
+func (a *applier) updateWLAN(ctx context.Context, current ruckusweb.Wlan, wlan WLAN) error {
+	w, err := a.wlanWire(current, wlan)
+	if err != nil {
+		return err
+	}
+	return a.c.Wlans().Update(ctx, w)
+}
+
+// wlanWire applies the settings in wlan to w.
+func (a *applier) wlanWire(w ruckusweb.Wlan, wlan WLAN) (ruckusweb.Wlan, error) {
+	w.Ssid = wlan.SSID
+	w.Description = wlan.Description
+	w.VlanID = wlan.VLAN
+	w.CloseSystem = wlan.Hidden
+	w.IsGuest = wlan.Guest
+	w.ClientIsolation = ruckusweb.EnabledBool(wlan.ClientIsolation)
+
+	w.AcctsvrID = 0
+	if wlan.AccountingServer != "" {
+		id, ok := a.aaaIDs[wlan.AccountingServer]
+		if !ok {
+			return w, fmt.Errorf("unknown accounting server %q", wlan.AccountingServer)
+		}
+		w.AcctsvrID = id
+	}
+
+	w.Encryption = wlan.Encryption
+	switch wlan.Encryption {
+	case ruckusweb.WlanEncryptionWpa2, ruckusweb.WlanEncryptionWpa2Wpa3Mixed, ruckusweb.WlanEncryptionWpa3:
+		wpa := ruckusweb.WlanWpa{Cipher: "aes"}
+		if w.Wpa != nil {
+			wpa = *w.Wpa
+		}
+		wpa.Passphrase, wpa.XPassphrase = "", ""
+		wpa.SaePassphrase, wpa.XSaePassphrase = "", ""
+		if wlan.Encryption != ruckusweb.WlanEncryptionWpa3 {
+			wpa.Passphrase, wpa.XPassphrase = wlan.Passphrase, wlan.Passphrase
+		}
+		if wlan.Encryption != ruckusweb.WlanEncryptionWpa2 {
+			wpa.SaePassphrase, wpa.XSaePassphrase = wlan.Passphrase, wlan.Passphrase
+		}
+		w.Wpa = &wpa
+	default:
+		w.Wpa = nil
+	}
+	return w, nil
+}
+
+func (a *applier) createAPGroup(ctx context.Context, group APGroup) error {
+	// New groups start with the radio settings of the System Default group
+	var g ruckusweb.APGroup
+	for _, existing := range a.state.apGroups {
+		if existing.Name == defaultAPGroup {
+			g = existing
+			break
+		}
+	}
+	g.Name = group.Name
+
+	if err := a.apGroupWire(&g, group); err != nil {
+		return err
+	}
+	if created, err := a.c.APGroups().Create(ctx, g); err != nil {
+		return err
+	} else {
+		a.apGroupIDs[group.Name] = created.ID
+	}
+	return nil
+}
+
+func (a *applier) updateAPGroup(ctx context.Context, current ruckusweb.APGroup, group APGroup) error {
+	if err := a.apGroupWire(&current, group); err != nil {
+		return err
+	}
+	return a.c.APGroups().Update(ctx, current)
+}
+
+// apGroupWire applies the settings in group to g.
+func (a *applier) apGroupWire(g *ruckusweb.APGroup, group APGroup) error {
+	g.Description = group.Description
+	g.Wlangroup.Wlansvc = make([]ruckusweb.APGroupWlansvc, 0, len(group.WLANs))
+	for _, name := range group.WLANs {
+		id, ok := a.wlanIDs[name]
+		if !ok {
+			return fmt.Errorf("unknown wlan %q", name)
+		}
+		g.Wlangroup.Wlansvc = append(g.Wlangroup.Wlansvc, ruckusweb.APGroupWlansvc{ID: id})
+	}
+	return nil
+}
+
+func (a *applier) updateAP(ctx context.Context, current ruckusweb.AP, ap AP) error {
+	if ap.Name != "" {
+		current.Devname = ap.Name
+	}
+	if ap.Description != "" {
+		current.Description = ap.Description
+	}
+	if ap.Location != "" {
+		current.Location = ap.Location
+	}
+	id, ok := a.apGroupIDs[ap.Group]
+	if !ok {
+		return fmt.Errorf("unknown ap-group %q", ap.Group)
+	}
+	current.GroupID = id
+
+	return a.c.APs().Update(ctx, current)
+}
+
+func (a *applier) updateSNMP(ctx context.Context, settings SNMP) error {
+	if settings.V2 != nil {
+		if err := a.c.SNMP().SetV2(ctx, *settings.V2); err != nil {
+			return err
+		}
+	}
+	if settings.V3 != nil {
+		if err := a.c.SNMP().SetV3(ctx, *settings.V3); err != nil {
+			return err
+		}
+	}
+	if settings.Trap != nil {
+		if err := a.c.SNMP().SetTrap(ctx, *settings.Trap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
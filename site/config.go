@@ -0,0 +1,156 @@
+// Package site reconciles an Unleashed network with a desired-state document.
+//
+// A Config describes the AAA servers, WLANs, AP groups, APs and SNMP settings a site should have. Compute compares it
+// with the network's current state and returns a Plan of the creates, updates and deletes needed to get there, which
+// can be reviewed and then applied. Objects are matched by name, or by MAC address for APs, so the same Config can be
+// applied to many sites.
+//
+// Each section of a Config is optional. A section which is absent leaves that kind of object alone, while a section
+// which is present but empty deletes every existing object of that kind.
+package site
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	AAA      []AAAServer `json:"aaa,omitempty" yaml:"aaa,omitempty"`
+	WLANs    []WLAN      `json:"wlans,omitempty" yaml:"wlans,omitempty"`
+	APGroups []APGroup   `json:"ap_groups,omitempty" yaml:"ap_groups,omitempty"`
+	APs      []AP        `json:"aps,omitempty" yaml:"aps,omitempty"`
+	SNMP     *SNMP       `json:"snmp,omitempty" yaml:"snmp,omitempty"`
+}
+
+// AAAServer is a RADIUS or Active Directory server.
+type AAAServer struct {
+	Name string `json:"name" yaml:"name"`
+	// Type is "radius-auth" for RADIUS authentication, "radius-acct" for RADIUS accounting, or "ad" for Active
+	// Directory. The type of an existing server cannot be changed.
+	Type string `json:"type" yaml:"type"`
+	// GroupAttribute is the attribute used to map users to roles, e.g. "memberOf".
+	GroupAttribute string `json:"group_attribute,omitempty" yaml:"group_attribute,omitempty"`
+
+	// Primary and Secondary are used by RADIUS servers.
+	Primary   *RadiusEndpoint `json:"primary,omitempty" yaml:"primary,omitempty"`
+	Secondary *RadiusEndpoint `json:"secondary,omitempty" yaml:"secondary,omitempty"`
+
+	// The remaining fields are used by Active Directory servers.
+	Server        string `json:"server,omitempty" yaml:"server,omitempty"`
+	Port          uint16 `json:"port,omitempty" yaml:"port,omitempty"`
+	GlobalCatalog bool   `json:"global_catalog,omitempty" yaml:"global_catalog,omitempty"`
+	SearchBase    string `json:"search_base,omitempty" yaml:"search_base,omitempty"`
+	AdminDN       string `json:"admin_dn,omitempty" yaml:"admin_dn,omitempty"`
+	AdminPassword string `json:"admin_password,omitempty" yaml:"admin_password,omitempty"`
+}
+
+type RadiusEndpoint struct {
+	Address string `json:"address" yaml:"address"`
+	Port    uint16 `json:"port" yaml:"port"`
+	Secret  string `json:"secret" yaml:"secret"`
+}
+
+type WLAN struct {
+	Name string `json:"name" yaml:"name"`
+	// SSID defaults to Name.
+	SSID        string `json:"ssid,omitempty" yaml:"ssid,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Encryption is "none", "wpa2", "wpa23mixed", "wpa3" or "owe".
+	Encryption ruckusweb.WlanEncryption `json:"encryption" yaml:"encryption"`
+	// Passphrase is required for WPA2 and WPA3 encryption.
+	Passphrase string `json:"passphrase,omitempty" yaml:"passphrase,omitempty"`
+
+	// VLAN defaults to 1.
+	VLAN            int  `json:"vlan,omitempty" yaml:"vlan,omitempty"`
+	Hidden          bool `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	Guest           bool `json:"guest,omitempty" yaml:"guest,omitempty"`
+	ClientIsolation bool `json:"client_isolation,omitempty" yaml:"client_isolation,omitempty"`
+
+	// AccountingServer names a "radius-acct" AAAServer.
+	AccountingServer string `json:"accounting_server,omitempty" yaml:"accounting_server,omitempty"`
+}
+
+func (w WLAN) ssid() string {
+	if w.SSID == "" {
+		return w.Name
+	}
+	return w.SSID
+}
+
+func (w WLAN) description() string {
+	if w.Description == "" {
+		return w.Name
+	}
+	return w.Description
+}
+
+func (w WLAN) vlan() int {
+	if w.VLAN == 0 {
+		return 1
+	}
+	return w.VLAN
+}
+
+type APGroup struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// WLANs names the WLANs broadcast by APs in the group.
+	WLANs []string `json:"wlans" yaml:"wlans"`
+}
+
+// AP describes an AP which has already joined the network. Empty fields other than Group are left unchanged.
+type AP struct {
+	MAC         string `json:"mac" yaml:"mac"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Location    string `json:"location,omitempty" yaml:"location,omitempty"`
+	// Group names an APGroup, defaulting to "System Default".
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+func (a AP) group() string {
+	if a.Group == "" {
+		return defaultAPGroup
+	}
+	return a.Group
+}
+
+// SNMP replaces each of the device's SNMP settings which is present.
+type SNMP struct {
+	V2   *ruckusweb.SNMPv2   `json:"v2,omitempty" yaml:"v2,omitempty"`
+	V3   *ruckusweb.SNMPv3   `json:"v3,omitempty" yaml:"v3,omitempty"`
+	Trap *ruckusweb.SNMPTrap `json:"trap,omitempty" yaml:"trap,omitempty"`
+}
+
+// Parse decodes a Config from JSON or YAML.
+func Parse(data []byte) (*Config, error) {
+	var config Config
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&config); err != nil {
+			return nil, err
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&config); err != nil {
+			return nil, err
+		}
+	}
+	return &config, nil
+}
+
+// Load reads and decodes a Config from a JSON or YAML file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
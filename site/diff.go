@@ -0,0 +1,222 @@
+package site
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+// differ accumulates a human-readable description of changed fields.
+type differ []string
+
+func (d *differ) value(name string, have, want any) {
+	if fmt.Sprint(have) == fmt.Sprint(want) {
+		return
+	}
+	format := "%s: %v -> %v"
+	if _, ok := want.(string); ok {
+		format = "%s: %q -> %q"
+	}
+	*d = append(*d, fmt.Sprintf(format, name, have, want))
+}
+
+func (d *differ) secret(name, have, want string) {
+	if have != want {
+		*d = append(*d, name+": changed")
+	}
+}
+
+// fields compares each exported field of two structs, or pointers to structs, without revealing their values.
+func (d *differ) fields(prefix string, have, want any) {
+	hv, wv := reflect.Indirect(reflect.ValueOf(have)), reflect.Indirect(reflect.ValueOf(want))
+	if !hv.IsValid() {
+		hv = reflect.Zero(wv.Type())
+	}
+	for i := 0; i < wv.NumField(); i++ {
+		field := wv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if fmt.Sprint(hv.Field(i).Interface()) != fmt.Sprint(wv.Field(i).Interface()) {
+			*d = append(*d, prefix+"."+field.Name+": changed")
+		}
+	}
+}
+
+func aaaFromWire(server ruckusweb.AaaServer) AAAServer {
+	switch s := server.(type) {
+	case *ruckusweb.AaaRadius:
+		out := AAAServer{
+			Name:           s.AaaEntry.Name,
+			Type:           s.Type,
+			GroupAttribute: s.GroupString,
+			Primary:        radiusEndpointFromWire(s.PrimaryRadius),
+		}
+		if s.Backup {
+			out.Secondary = radiusEndpointFromWire(s.SecondaryRadius)
+		}
+		return out
+	case *ruckusweb.AaaActiveDirectory:
+		out := AAAServer{
+			Name:           s.AaaEntry.Name,
+			Type:           s.Type,
+			GroupAttribute: s.GroupString,
+			Server:         s.Server1,
+			Port:           s.Port,
+			GlobalCatalog:  bool(s.GlobalCatalog),
+			SearchBase:     s.SearchBase,
+			AdminDN:        s.AdminDn,
+			AdminPassword:  s.AdminPwd,
+		}
+		if out.AdminPassword == "" {
+			out.AdminPassword = s.XAdminPwd
+		}
+		return out
+	default:
+		return AAAServer{Name: server.Name()}
+	}
+}
+
+func radiusEndpointFromWire(e *ruckusweb.AaaRadiusEndpoint) *RadiusEndpoint {
+	if e == nil || e.Ip == "" {
+		return nil
+	}
+	out := &RadiusEndpoint{Address: e.Ip, Port: e.Port, Secret: e.Secret}
+	if out.Secret == "" {
+		out.Secret = e.XSecret
+	}
+	return out
+}
+
+func diffAAA(have, want AAAServer) []string {
+	var d differ
+	d.value("group_attribute", have.GroupAttribute, want.GroupAttribute)
+	if want.Type == "ad" {
+		d.value("server", have.Server, want.Server)
+		d.value("port", have.Port, want.Port)
+		d.value("global_catalog", have.GlobalCatalog, want.GlobalCatalog)
+		d.value("search_base", have.SearchBase, want.SearchBase)
+		d.value("admin_dn", have.AdminDN, want.AdminDN)
+		d.secret("admin_password", have.AdminPassword, want.AdminPassword)
+	} else {
+		diffRadiusEndpoint(&d, "primary", have.Primary, want.Primary)
+		diffRadiusEndpoint(&d, "secondary", have.Secondary, want.Secondary)
+	}
+	return d
+}
+
+func diffRadiusEndpoint(d *differ, prefix string, have, want *RadiusEndpoint) {
+	if have == nil && want == nil {
+		return
+	} else if have == nil {
+		*d = append(*d, prefix+": added")
+		return
+	} else if want == nil {
+		*d = append(*d, prefix+": removed")
+		return
+	}
+	d.value(prefix+".address", have.Address, want.Address)
+	d.value(prefix+".port", have.Port, want.Port)
+	d.secret(prefix+".secret", have.Secret, want.Secret)
+}
+
+func wlanFromWire(w ruckusweb.Wlan, n names) WLAN {
+	out := WLAN{
+		Name:             w.Name,
+		SSID:             w.Ssid,
+		Description:      w.Description,
+		Encryption:       w.Encryption,
+		VLAN:             w.VlanID,
+		Hidden:           w.CloseSystem,
+		Guest:            w.IsGuest,
+		ClientIsolation:  bool(w.ClientIsolation),
+		AccountingServer: n.aaa[w.AcctsvrID],
+	}
+	if w.Wpa != nil {
+		switch w.Encryption {
+		case ruckusweb.WlanEncryptionWpa3:
+			out.Passphrase = firstNonEmpty(w.Wpa.SaePassphrase, w.Wpa.XSaePassphrase)
+		default:
+			out.Passphrase = firstNonEmpty(w.Wpa.Passphrase, w.Wpa.XPassphrase)
+		}
+	}
+	return out
+}
+
+func diffWLAN(have, want WLAN) []string {
+	var d differ
+	d.value("ssid", have.SSID, want.SSID)
+	d.value("description", have.Description, want.Description)
+	d.value("encryption", encryptionName(have.Encryption), encryptionName(want.Encryption))
+	d.secret("passphrase", have.Passphrase, want.Passphrase)
+	d.value("vlan", have.VLAN, want.VLAN)
+	d.value("hidden", have.Hidden, want.Hidden)
+	d.value("guest", have.Guest, want.Guest)
+	d.value("client_isolation", have.ClientIsolation, want.ClientIsolation)
+	d.value("accounting_server", have.AccountingServer, want.AccountingServer)
+	return d
+}
+
+func apGroupFromWire(g ruckusweb.APGroup, n names) APGroup {
+	out := APGroup{
+		Name:        g.Name,
+		Description: g.Description,
+		WLANs:       []string{},
+	}
+	for _, svc := range g.Wlangroup.Wlansvc {
+		if name, ok := n.wlans[svc.ID]; ok {
+			out.WLANs = append(out.WLANs, name)
+		}
+	}
+	sort.Strings(out.WLANs)
+	return out
+}
+
+func diffAPGroup(have, want APGroup) []string {
+	var d differ
+	d.value("description", have.Description, want.Description)
+	d.value("wlans", have.WLANs, want.WLANs)
+	return d
+}
+
+func apFromWire(ap ruckusweb.AP, n names) AP {
+	return AP{
+		MAC:         net.HardwareAddr(ap.Mac).String(),
+		Name:        ap.Devname,
+		Description: ap.Description,
+		Location:    ap.Location,
+		Group:       n.apGroups[ap.GroupID],
+	}
+}
+
+func diffAP(have, want AP) []string {
+	var d differ
+	if want.Name != "" {
+		d.value("name", have.Name, want.Name)
+	}
+	if want.Description != "" {
+		d.value("description", have.Description, want.Description)
+	}
+	if want.Location != "" {
+		d.value("location", have.Location, want.Location)
+	}
+	d.value("group", have.Group, want.Group)
+	return d
+}
+
+func encryptionName(e ruckusweb.WlanEncryption) string {
+	text, _ := e.MarshalText()
+	return string(text)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,587 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+const defaultAPGroup = "System Default"
+
+type Action int
+
+const (
+	ActionCreate Action = iota
+	ActionUpdate
+	ActionDelete
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+type Kind string
+
+const (
+	KindAAA     Kind = "aaa"
+	KindWLAN    Kind = "wlan"
+	KindAPGroup Kind = "ap-group"
+	KindAP      Kind = "ap"
+	KindSNMP    Kind = "snmp"
+)
+
+// Change is a single step of a Plan.
+type Change struct {
+	Action Action
+	Kind   Kind
+	// Name is the object's name, or its MAC address for APs.
+	Name string
+	// Details describes each field an update changes. Secrets are reported as changed without revealing their values.
+	Details []string
+
+	apply func(ctx context.Context, a *applier) error
+}
+
+func (c Change) String() string {
+	var b strings.Builder
+	switch c.Action {
+	case ActionCreate:
+		b.WriteString("+ ")
+	case ActionUpdate:
+		b.WriteString("~ ")
+	case ActionDelete:
+		b.WriteString("- ")
+	}
+	fmt.Fprintf(&b, "%s %s %q", c.Action, c.Kind, c.Name)
+	for _, detail := range c.Details {
+		b.WriteString("\n    ")
+		b.WriteString(detail)
+	}
+	return b.String()
+}
+
+// Plan is the set of changes needed to reconcile a network with a Config, listed in the order Apply makes them.
+type Plan struct {
+	Changes []Change
+
+	state *state
+}
+
+func (p *Plan) String() string {
+	if len(p.Changes) == 0 {
+		return "No changes."
+	}
+	lines := make([]string, len(p.Changes))
+	for i, change := range p.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// state is the part of a network's configuration which a Config can manage.
+type state struct {
+	aaa      []ruckusweb.AaaServer
+	wlans    []ruckusweb.Wlan
+	apGroups []ruckusweb.APGroup
+	aps      []ruckusweb.AP
+
+	// snmp settings are only read if the Config manages them
+	snmpV2   *ruckusweb.SNMPv2
+	snmpV3   *ruckusweb.SNMPv3
+	snmpTrap *ruckusweb.SNMPTrap
+}
+
+func fetchState(ctx context.Context, c *ruckusweb.Client, config *Config) (*state, error) {
+	var s state
+	var err error
+	if s.aaa, err = c.AAA().List(ctx); err != nil {
+		return nil, err
+	}
+	if s.wlans, err = c.Wlans().List(ctx); err != nil {
+		return nil, err
+	}
+	if s.apGroups, err = c.APGroups().List(ctx); err != nil {
+		return nil, err
+	}
+	if s.aps, err = c.APs().List(ctx); err != nil {
+		return nil, err
+	}
+	if config.SNMP != nil {
+		if s.snmpV2, err = c.SNMP().GetV2(ctx); err != nil {
+			return nil, err
+		}
+		if s.snmpV3, err = c.SNMP().GetV3(ctx); err != nil {
+			return nil, err
+		}
+		if s.snmpTrap, err = c.SNMP().GetTrap(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return &s, nil
+}
+
+// Compute reads the network's current state and plans the changes needed to make it match config. It makes no
+// changes itself, and fails rather than plan to delete an object which something config leaves alone still uses.
+func Compute(ctx context.Context, c *ruckusweb.Client, config *Config) (*Plan, error) {
+	if s, err := fetchState(ctx, c, config); err != nil {
+		return nil, err
+	} else {
+		return plan(config, s)
+	}
+}
+
+func plan(config *Config, s *state) (*Plan, error) {
+	p := &planner{config: config, state: s, names: newNames(s)}
+	for _, step := range []func() error{
+		p.aaa,
+		p.wlans,
+		p.apGroups,
+		p.aps,
+		p.deletions,
+		p.snmp,
+	} {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return &Plan{Changes: p.changes, state: s}, nil
+}
+
+// names maps the IDs of existing objects to their names.
+type names struct {
+	aaa      map[int]string
+	wlans    map[int]string
+	apGroups map[int]string
+}
+
+func newNames(s *state) names {
+	n := names{
+		aaa:      make(map[int]string, len(s.aaa)),
+		wlans:    make(map[int]string, len(s.wlans)),
+		apGroups: make(map[int]string, len(s.apGroups)),
+	}
+	for _, server := range s.aaa {
+		n.aaa[server.ID()] = server.Name()
+	}
+	for _, wlan := range s.wlans {
+		n.wlans[wlan.ID] = wlan.Name
+	}
+	for _, group := range s.apGroups {
+		n.apGroups[group.ID] = group.Name
+	}
+	return n
+}
+
+type planner struct {
+	config  *Config
+	state   *state
+	names   names
+	changes []Change
+
+	// deletes are collected as each section is planned and emitted after every create and update
+	aaaDeletes, wlanDeletes, apGroupDeletes []Change
+}
+
+func (p *planner) add(change Change) {
+	p.changes = append(p.changes, change)
+}
+
+func (p *planner) aaa() error {
+	if p.config.AAA == nil {
+		return nil
+	}
+
+	current := make(map[string]ruckusweb.AaaServer, len(p.state.aaa))
+	for _, server := range p.state.aaa {
+		current[server.Name()] = server
+	}
+
+	desired := make(map[string]bool, len(p.config.AAA))
+	for _, server := range p.config.AAA {
+		server := server
+		if server.Name == "" {
+			return fmt.Errorf("aaa: name must be set")
+		} else if desired[server.Name] {
+			return fmt.Errorf("aaa %q: defined more than once", server.Name)
+		}
+		desired[server.Name] = true
+
+		switch server.Type {
+		case "radius-auth", "radius-acct":
+			if server.Primary == nil {
+				return fmt.Errorf("aaa %q: primary must be set", server.Name)
+			}
+		case "ad":
+			if server.Server == "" {
+				return fmt.Errorf("aaa %q: server must be set", server.Name)
+			}
+		default:
+			return fmt.Errorf("aaa %q: invalid type %q", server.Name, server.Type)
+		}
+
+		existing, ok := current[server.Name]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindAAA, Name: server.Name, apply: func(ctx context.Context, a *applier) error {
+				return a.createAAA(ctx, server)
+			}})
+			continue
+		}
+
+		have := aaaFromWire(existing)
+		if have.Type != server.Type {
+			return fmt.Errorf("aaa %q: cannot change type from %q to %q", server.Name, have.Type, server.Type)
+		}
+		if details := diffAAA(have, server); len(details) > 0 {
+			if !existing.Editable() {
+				return fmt.Errorf("aaa %q: server is not editable", server.Name)
+			}
+			p.add(Change{Action: ActionUpdate, Kind: KindAAA, Name: server.Name, Details: details, apply: func(ctx context.Context, a *applier) error {
+				return a.updateAAA(ctx, existing, server)
+			}})
+		}
+	}
+
+	for _, server := range p.state.aaa {
+		if !desired[server.Name()] && server.Editable() {
+			id := server.ID()
+			p.aaaDeletes = append(p.aaaDeletes, Change{Action: ActionDelete, Kind: KindAAA, Name: server.Name(), apply: func(ctx context.Context, a *applier) error {
+				return a.c.AAA().Delete(ctx, id)
+			}})
+		}
+	}
+	return nil
+}
+
+// aaaExists returns true if the named AAA server will exist once the plan is applied.
+func (p *planner) aaaExists(name string) bool {
+	if p.config.AAA != nil {
+		for _, server := range p.config.AAA {
+			if server.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, server := range p.state.aaa {
+		if server.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *planner) wlans() error {
+	if p.config.WLANs == nil {
+		return nil
+	}
+
+	current := make(map[string]*ruckusweb.Wlan, len(p.state.wlans))
+	for i := range p.state.wlans {
+		current[p.state.wlans[i].Name] = &p.state.wlans[i]
+	}
+
+	desired := make(map[string]bool, len(p.config.WLANs))
+	for _, wlan := range p.config.WLANs {
+		wlan := wlan.normalize()
+		if wlan.Name == "" {
+			return fmt.Errorf("wlan: name must be set")
+		} else if desired[wlan.Name] {
+			return fmt.Errorf("wlan %q: defined more than once", wlan.Name)
+		}
+		desired[wlan.Name] = true
+
+		switch wlan.Encryption {
+		case ruckusweb.WlanEncryptionWpa2, ruckusweb.WlanEncryptionWpa2Wpa3Mixed, ruckusweb.WlanEncryptionWpa3:
+			if wlan.Passphrase == "" {
+				return fmt.Errorf("wlan %q: passphrase must be set", wlan.Name)
+			}
+		default:
+			if wlan.Passphrase != "" {
+				return fmt.Errorf("wlan %q: passphrase requires WPA encryption", wlan.Name)
+			}
+		}
+		if wlan.AccountingServer != "" && !p.aaaExists(wlan.AccountingServer) {
+			return fmt.Errorf("wlan %q: unknown accounting server %q", wlan.Name, wlan.AccountingServer)
+		}
+
+		existing, ok := current[wlan.Name]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindWLAN, Name: wlan.Name, apply: func(ctx context.Context, a *applier) error {
+				return a.createWLAN(ctx, wlan)
+			}})
+			continue
+		}
+
+		if details := diffWLAN(wlanFromWire(*existing, p.names), wlan); len(details) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindWLAN, Name: wlan.Name, Details: details, apply: func(ctx context.Context, a *applier) error {
+				return a.updateWLAN(ctx, *existing, wlan)
+			}})
+		}
+	}
+
+	for _, wlan := range p.state.wlans {
+		if !desired[wlan.Name] {
+			id := wlan.ID
+			p.wlanDeletes = append(p.wlanDeletes, Change{Action: ActionDelete, Kind: KindWLAN, Name: wlan.Name, apply: func(ctx context.Context, a *applier) error {
+				return a.c.Wlans().Delete(ctx, id)
+			}})
+		}
+	}
+	return nil
+}
+
+// wlanExists returns true if the named WLAN will exist once the plan is applied.
+func (p *planner) wlanExists(name string) bool {
+	if p.config.WLANs != nil {
+		for _, wlan := range p.config.WLANs {
+			if wlan.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, wlan := range p.state.wlans {
+		if wlan.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *planner) apGroups() error {
+	if p.config.APGroups == nil {
+		return nil
+	}
+
+	current := make(map[string]*ruckusweb.APGroup, len(p.state.apGroups))
+	for i := range p.state.apGroups {
+		current[p.state.apGroups[i].Name] = &p.state.apGroups[i]
+	}
+
+	desired := make(map[string]bool, len(p.config.APGroups))
+	for _, group := range p.config.APGroups {
+		group := group.normalize()
+		if group.Name == "" {
+			return fmt.Errorf("ap-group: name must be set")
+		} else if desired[group.Name] {
+			return fmt.Errorf("ap-group %q: defined more than once", group.Name)
+		}
+		desired[group.Name] = true
+
+		for _, name := range group.WLANs {
+			if !p.wlanExists(name) {
+				return fmt.Errorf("ap-group %q: unknown wlan %q", group.Name, name)
+			}
+		}
+
+		existing, ok := current[group.Name]
+		if !ok {
+			p.add(Change{Action: ActionCreate, Kind: KindAPGroup, Name: group.Name, apply: func(ctx context.Context, a *applier) error {
+				return a.createAPGroup(ctx, group)
+			}})
+			continue
+		}
+
+		if details := diffAPGroup(apGroupFromWire(*existing, p.names), group); len(details) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindAPGroup, Name: group.Name, Details: details, apply: func(ctx context.Context, a *applier) error {
+				return a.updateAPGroup(ctx, *existing, group)
+			}})
+		}
+	}
+
+	for _, group := range p.state.apGroups {
+		if !desired[group.Name] && group.Name != defaultAPGroup {
+			id := group.ID
+			p.apGroupDeletes = append(p.apGroupDeletes, Change{Action: ActionDelete, Kind: KindAPGroup, Name: group.Name, apply: func(ctx context.Context, a *applier) error {
+				return a.c.APGroups().Delete(ctx, id)
+			}})
+		}
+	}
+	return nil
+}
+
+// apGroupExists returns true if the named AP group will exist once the plan is applied.
+func (p *planner) apGroupExists(name string) bool {
+	if name == defaultAPGroup {
+		return true
+	}
+	if p.config.APGroups != nil {
+		for _, group := range p.config.APGroups {
+			if group.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	for _, group := range p.state.apGroups {
+		if group.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *planner) aps() error {
+	if p.config.APs == nil {
+		return nil
+	}
+
+	current := make(map[string]*ruckusweb.AP, len(p.state.aps))
+	for i := range p.state.aps {
+		current[net.HardwareAddr(p.state.aps[i].Mac).String()] = &p.state.aps[i]
+	}
+
+	desired := make(map[string]bool, len(p.config.APs))
+	for _, ap := range p.config.APs {
+		ap := ap
+		mac, err := net.ParseMAC(ap.MAC)
+		if err != nil {
+			return fmt.Errorf("ap %q: %v", ap.MAC, err)
+		}
+		ap.MAC = mac.String()
+		ap.Group = ap.group()
+		if desired[ap.MAC] {
+			return fmt.Errorf("ap %q: defined more than once", ap.MAC)
+		}
+		desired[ap.MAC] = true
+
+		if !p.apGroupExists(ap.Group) {
+			return fmt.Errorf("ap %q: unknown ap-group %q", ap.MAC, ap.Group)
+		}
+
+		existing, ok := current[ap.MAC]
+		if !ok {
+			return fmt.Errorf("ap %q: not found; APs must join the network before they can be configured", ap.MAC)
+		}
+
+		if details := diffAP(apFromWire(*existing, p.names), ap); len(details) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindAP, Name: ap.MAC, Details: details, apply: func(ctx context.Context, a *applier) error {
+				return a.updateAP(ctx, *existing, ap)
+			}})
+		}
+	}
+	return nil
+}
+
+// deletions emits the deletes collected so far, removing objects only once nothing managed refers to them.
+func (p *planner) deletions() error {
+	if err := p.checkReferences(); err != nil {
+		return err
+	}
+	p.changes = append(p.changes, p.apGroupDeletes...)
+	p.changes = append(p.changes, p.wlanDeletes...)
+	p.changes = append(p.changes, p.aaaDeletes...)
+	return nil
+}
+
+// checkReferences fails if an object to be deleted is still used by one which remains once the plan is applied. The
+// references of objects in the Config were checked as they were planned, so this checks the objects left alone: WLANs
+// referring to AAA servers, AP groups broadcasting WLANs, and APs in AP groups.
+func (p *planner) checkReferences() error {
+	deleted := func(changes []Change) map[string]bool {
+		names := make(map[string]bool, len(changes))
+		for _, change := range changes {
+			names[change.Name] = true
+		}
+		return names
+	}
+	aaaDeleted, wlanDeleted, apGroupDeleted := deleted(p.aaaDeletes), deleted(p.wlanDeletes), deleted(p.apGroupDeletes)
+
+	configuredWlans := make(map[string]bool, len(p.config.WLANs))
+	for _, wlan := range p.config.WLANs {
+		configuredWlans[wlan.Name] = true
+	}
+	for _, wlan := range p.state.wlans {
+		if wlanDeleted[wlan.Name] {
+			continue
+		}
+		// Configured WLANs have their accounting server replaced, but keep their authentication server
+		if name := p.names.aaa[wlan.AcctsvrID]; aaaDeleted[name] && !configuredWlans[wlan.Name] {
+			return fmt.Errorf("aaa %q: cannot delete, still the accounting server of wlan %q", name, wlan.Name)
+		}
+		if id, err := strconv.Atoi(wlan.AuthsvrID); err == nil && aaaDeleted[p.names.aaa[id]] {
+			return fmt.Errorf("aaa %q: cannot delete, still the authentication server of wlan %q", p.names.aaa[id], wlan.Name)
+		}
+	}
+
+	configuredGroups := make(map[string]bool, len(p.config.APGroups))
+	for _, group := range p.config.APGroups {
+		configuredGroups[group.Name] = true
+	}
+	for _, group := range p.state.apGroups {
+		if apGroupDeleted[group.Name] || configuredGroups[group.Name] {
+			continue
+		}
+		for _, svc := range group.Wlangroup.Wlansvc {
+			if name := p.names.wlans[svc.ID]; wlanDeleted[name] {
+				return fmt.Errorf("wlan %q: cannot delete, still broadcast by ap-group %q", name, group.Name)
+			}
+		}
+	}
+
+	configuredAPs := make(map[string]bool, len(p.config.APs))
+	for _, ap := range p.config.APs {
+		// aps has already rejected invalid MACs
+		if mac, err := net.ParseMAC(ap.MAC); err == nil {
+			configuredAPs[mac.String()] = true
+		}
+	}
+	for _, ap := range p.state.aps {
+		mac := net.HardwareAddr(ap.Mac).String()
+		if name := p.names.apGroups[ap.GroupID]; apGroupDeleted[name] && !configuredAPs[mac] {
+			return fmt.Errorf("ap-group %q: cannot delete, still used by ap %q", name, mac)
+		}
+	}
+	return nil
+}
+
+func (p *planner) snmp() error {
+	desired := p.config.SNMP
+	if desired == nil {
+		return nil
+	}
+
+	var d differ
+	if desired.V2 != nil {
+		d.fields("v2", p.state.snmpV2, desired.V2)
+	}
+	if desired.V3 != nil {
+		d.fields("v3", p.state.snmpV3, desired.V3)
+	}
+	if desired.Trap != nil {
+		d.fields("trap", p.state.snmpTrap, desired.Trap)
+	}
+	if len(d) > 0 {
+		p.add(Change{Action: ActionUpdate, Kind: KindSNMP, Name: "system", Details: d, apply: func(ctx context.Context, a *applier) error {
+			return a.updateSNMP(ctx, *desired)
+		}})
+	}
+	return nil
+}
+
+func (w WLAN) normalize() WLAN {
+	w.SSID = w.ssid()
+	w.Description = w.description()
+	w.VLAN = w.vlan()
+	return w
+}
+
+func (g APGroup) normalize() APGroup {
+	g.WLANs = append([]string{}, g.WLANs...)
+	sort.Strings(g.WLANs)
+	return g
+}
@@ -0,0 +1,204 @@
+package site
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willglynn/ruckus-go/ruckusweb"
+)
+
+func testState() *state {
+	mac, _ := net.ParseMAC("00:00:00:00:01:01")
+	return &state{
+		aaa: []ruckusweb.AaaServer{
+			&ruckusweb.AaaRadius{
+				AaaEntry:      ruckusweb.AaaEntry{ID: 1, Name: "acct", Type: "radius-acct", Editable: true},
+				PrimaryRadius: &ruckusweb.AaaRadiusEndpoint{Ip: "10.0.0.1", Port: 1813, Secret: "s3cret"},
+			},
+		},
+		wlans: []ruckusweb.Wlan{
+			{ID: 1, Name: "corp", Ssid: "corp", Description: "corp", VlanID: 1, Encryption: ruckusweb.WlanEncryptionWpa2, Wpa: &ruckusweb.WlanWpa{Passphrase: "password1"}},
+			{ID: 2, Name: "old", Ssid: "old", Description: "old", VlanID: 1},
+		},
+		apGroups: []ruckusweb.APGroup{
+			{ID: 1, Name: "System Default"},
+		},
+		aps: []ruckusweb.AP{
+			{ID: 1, Mac: ruckusweb.MacAddress(mac), Devname: "ap1", GroupID: 1},
+		},
+	}
+}
+
+func changeStrings(p *Plan) []string {
+	var out []string
+	for _, change := range p.Changes {
+		out = append(out, change.String())
+	}
+	return out
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{
+			"unmanaged",
+			Config{},
+			nil,
+		},
+		{
+			"unchanged",
+			Config{
+				AAA: []AAAServer{{Name: "acct", Type: "radius-acct", Primary: &RadiusEndpoint{Address: "10.0.0.1", Port: 1813, Secret: "s3cret"}}},
+				WLANs: []WLAN{
+					{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password1"},
+					{Name: "old"},
+				},
+				APs: []AP{{MAC: "00-00-00-00-01-01"}},
+			},
+			nil,
+		},
+		{
+			"wlans",
+			Config{
+				WLANs: []WLAN{
+					{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password2", VLAN: 10},
+					{Name: "guest", Guest: true},
+				},
+			},
+			[]string{
+				"~ update wlan \"corp\"\n    passphrase: changed\n    vlan: 1 -> 10",
+				"+ create wlan \"guest\"",
+				"- delete wlan \"old\"",
+			},
+		},
+		{
+			"dependency order",
+			Config{
+				AAA:      []AAAServer{},
+				WLANs:    []WLAN{{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password1"}},
+				APGroups: []APGroup{{Name: "System Default", WLANs: []string{"corp"}}, {Name: "lobby", WLANs: []string{"corp"}}},
+				APs:      []AP{{MAC: "00:00:00:00:01:01", Name: "lobby-1", Group: "lobby"}},
+			},
+			[]string{
+				"~ update ap-group \"System Default\"\n    wlans: [] -> [corp]",
+				"+ create ap-group \"lobby\"",
+				"~ update ap \"00:00:00:00:01:01\"\n    name: \"ap1\" -> \"lobby-1\"\n    group: \"System Default\" -> \"lobby\"",
+				"- delete wlan \"old\"",
+				"- delete aaa \"acct\"",
+			},
+		},
+		{
+			"snmp",
+			Config{SNMP: &SNMP{V2: &ruckusweb.SNMPv2{Enabled: true, RoCommunity: "public"}}},
+			[]string{
+				"~ update snmp \"system\"\n    v2.Enabled: changed\n    v2.RoCommunity: changed",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testState()
+			s.snmpV2 = &ruckusweb.SNMPv2{}
+			p, err := plan(&tt.config, s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, changeStrings(p))
+		})
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		state  func(*state)
+		config Config
+		want   string
+	}{
+		{
+			name:   "unknown accounting server",
+			config: Config{AAA: []AAAServer{}, WLANs: []WLAN{{Name: "corp", AccountingServer: "acct"}}},
+			want:   `wlan "corp": unknown accounting server "acct"`,
+		},
+		{
+			name:   "unknown wlan",
+			config: Config{APGroups: []APGroup{{Name: "lobby", WLANs: []string{"guest"}}}},
+			want:   `ap-group "lobby": unknown wlan "guest"`,
+		},
+		{
+			name:   "unknown ap",
+			config: Config{APs: []AP{{MAC: "00:00:00:00:01:02"}}},
+			want:   `ap "00:00:00:00:01:02": not found; APs must join the network before they can be configured`,
+		},
+		{
+			name:   "type change",
+			config: Config{AAA: []AAAServer{{Name: "acct", Type: "ad", Server: "dc1"}}},
+			want:   `aaa "acct": cannot change type from "radius-acct" to "ad"`,
+		},
+		{
+			name:   "missing passphrase",
+			config: Config{WLANs: []WLAN{{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa3}}},
+			want:   `wlan "corp": passphrase must be set`,
+		},
+		{
+			name: "accounting server in use",
+			state: func(s *state) {
+				s.wlans[1].AcctsvrID = 1
+			},
+			config: Config{AAA: []AAAServer{}},
+			want:   `aaa "acct": cannot delete, still the accounting server of wlan "old"`,
+		},
+		{
+			name: "authentication server in use",
+			state: func(s *state) {
+				s.wlans[0].AuthsvrID = "1"
+			},
+			config: Config{AAA: []AAAServer{}, WLANs: []WLAN{{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password1"}}},
+			want:   `aaa "acct": cannot delete, still the authentication server of wlan "corp"`,
+		},
+		{
+			name: "wlan in use",
+			state: func(s *state) {
+				s.apGroups[0].Wlangroup.Wlansvc = []ruckusweb.APGroupWlansvc{{ID: 1}, {ID: 2}}
+			},
+			config: Config{WLANs: []WLAN{{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password1"}}},
+			want:   `wlan "old": cannot delete, still broadcast by ap-group "System Default"`,
+		},
+		{
+			name: "ap-group in use",
+			state: func(s *state) {
+				s.apGroups = append(s.apGroups, ruckusweb.APGroup{ID: 2, Name: "lobby"})
+				s.aps[0].GroupID = 2
+			},
+			config: Config{APGroups: []APGroup{}},
+			want:   `ap-group "lobby": cannot delete, still used by ap "00:00:00:00:01:01"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testState()
+			if tt.state != nil {
+				tt.state(s)
+			}
+			_, err := plan(&tt.config, s)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	config, err := Parse([]byte(`
+wlans:
+  - name: corp
+    encryption: wpa2
+    passphrase: password1
+ap_groups: []
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []WLAN{{Name: "corp", Encryption: ruckusweb.WlanEncryptionWpa2, Passphrase: "password1"}}, config.WLANs)
+	assert.NotNil(t, config.APGroups)
+	assert.Nil(t, config.APs)
+}